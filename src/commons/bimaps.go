@@ -0,0 +1,58 @@
+package commons
+
+// BiMap is a one to one mapping between keys and values, to look up in both directions.
+// Zero value is not usable, use NewBiMap
+type BiMap[K, V comparable] struct {
+	// values maps keys to values
+	values map[K]V
+	// keys maps values to keys
+	keys map[V]K
+}
+
+// NewBiMap builds an empty bimap
+func NewBiMap[K, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		values: make(map[K]V),
+		keys:   make(map[V]K),
+	}
+}
+
+// Put links key and value.
+// To keep the mapping one to one, previous links of key and of value are removed
+func (b *BiMap[K, V]) Put(key K, value V) {
+	if previousValue, found := b.values[key]; found {
+		delete(b.keys, previousValue)
+	}
+
+	if previousKey, found := b.keys[value]; found {
+		delete(b.values, previousKey)
+	}
+
+	b.values[key] = value
+	b.keys[value] = key
+}
+
+// GetByKey returns the value linked to key, if any
+func (b *BiMap[K, V]) GetByKey(key K) (V, bool) {
+	value, found := b.values[key]
+	return value, found
+}
+
+// GetByValue returns the key linked to value, if any
+func (b *BiMap[K, V]) GetByValue(value V) (K, bool) {
+	key, found := b.keys[value]
+	return key, found
+}
+
+// Delete removes key and its linked value, no effect if key is not in the bimap
+func (b *BiMap[K, V]) Delete(key K) {
+	if value, found := b.values[key]; found {
+		delete(b.keys, value)
+		delete(b.values, key)
+	}
+}
+
+// Len returns the number of links in the bimap
+func (b *BiMap[K, V]) Len() int {
+	return len(b.values)
+}
@@ -0,0 +1,86 @@
+package commons
+
+// Set is a set of comparable elements.
+// Zero value is not usable, use NewSet
+type Set[T comparable] struct {
+	// elements are the keys of the map
+	elements map[T]bool
+}
+
+// NewSet builds a set containing values (duplicates are ignored)
+func NewSet[T comparable](values ...T) *Set[T] {
+	result := &Set[T]{elements: make(map[T]bool, len(values))}
+	for _, value := range values {
+		result.elements[value] = true
+	}
+
+	return result
+}
+
+// Add adds value to the set, no effect if value is already in the set
+func (s *Set[T]) Add(value T) {
+	s.elements[value] = true
+}
+
+// Contains returns true if value is in the set
+func (s *Set[T]) Contains(value T) bool {
+	return s.elements[value]
+}
+
+// Remove removes value from the set, no effect if value is not in the set
+func (s *Set[T]) Remove(value T) {
+	delete(s.elements, value)
+}
+
+// Len returns the number of elements in the set
+func (s *Set[T]) Len() int {
+	return len(s.elements)
+}
+
+// Slice returns the elements of the set, in no specific order
+func (s *Set[T]) Slice() []T {
+	result := make([]T, 0, len(s.elements))
+	for value := range s.elements {
+		result = append(result, value)
+	}
+
+	return result
+}
+
+// Union returns a new set with elements in s or in other
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := NewSet[T]()
+	for value := range s.elements {
+		result.elements[value] = true
+	}
+
+	for value := range other.elements {
+		result.elements[value] = true
+	}
+
+	return result
+}
+
+// Intersect returns a new set with elements both in s and in other
+func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
+	result := NewSet[T]()
+	for value := range s.elements {
+		if other.elements[value] {
+			result.elements[value] = true
+		}
+	}
+
+	return result
+}
+
+// Difference returns a new set with elements in s but not in other
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	result := NewSet[T]()
+	for value := range s.elements {
+		if !other.elements[value] {
+			result.elements[value] = true
+		}
+	}
+
+	return result
+}
@@ -157,3 +157,51 @@ func SlicesFilter[T any](base []T, keepPredicate func(T) bool) []T {
 
 	return result
 }
+
+// SliceGroupByOrdered groups items by key.
+// It returns keys in the order they first appear in items, and the groups per key (items order is kept)
+func SliceGroupByOrdered[T any, K comparable](items []T, key func(T) K) ([]K, map[K][]T) {
+	var keys []K
+	groups := make(map[K][]T)
+	for _, item := range items {
+		value := key(item)
+		if _, found := groups[value]; !found {
+			keys = append(keys, value)
+		}
+
+		groups[value] = append(groups[value], item)
+	}
+
+	return keys, groups
+}
+
+// SlicePartition splits items into the elements matching the predicate and the other ones.
+// Order of items is kept in both results
+func SlicePartition[T any](items []T, pred func(T) bool) (matching, rest []T) {
+	for _, element := range items {
+		if pred(element) {
+			matching = append(matching, element)
+		} else {
+			rest = append(rest, element)
+		}
+	}
+
+	return matching, rest
+}
+
+// SliceChunk splits items into consecutive chunks of size elements, last one may be smaller.
+// Chunks share memory with items but their capacity is capped, so appending to a chunk does not alter items.
+// SPECIAL CASE: it returns nil if size is not positive
+func SliceChunk[T any](items []T, size int) [][]T {
+	if size <= 0 {
+		return nil
+	}
+
+	var result [][]T
+	for start := 0; start < len(items); start += size {
+		end := min(start+size, len(items))
+		result = append(result, items[start:end:end])
+	}
+
+	return result
+}
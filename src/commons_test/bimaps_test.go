@@ -0,0 +1,56 @@
+package commons_test
+
+import (
+	"testing"
+
+	"github.com/zefrenchwan/perspectives.git/commons"
+)
+
+func TestBiMapBothDirections(t *testing.T) {
+	mapping := commons.NewBiMap[string, int]()
+	mapping.Put("a", 1)
+	mapping.Put("b", 2)
+
+	if value, found := mapping.GetByKey("a"); !found || value != 1 {
+		t.Fail()
+	} else if key, found := mapping.GetByValue(2); !found || key != "b" {
+		t.Fail()
+	} else if mapping.Len() != 2 {
+		t.Fail()
+	}
+
+	mapping.Delete("a")
+	if _, found := mapping.GetByKey("a"); found {
+		t.Fail()
+	} else if _, found := mapping.GetByValue(1); found {
+		t.Fail()
+	} else if mapping.Len() != 1 {
+		t.Fail()
+	}
+
+	// no effect
+	mapping.Delete("unknown")
+	if mapping.Len() != 1 {
+		t.Fail()
+	}
+}
+
+func TestBiMapPutReplaces(t *testing.T) {
+	mapping := commons.NewBiMap[string, int]()
+	mapping.Put("a", 1)
+	mapping.Put("b", 2)
+
+	// value 2 moves to a: links a-1 and b-2 are removed
+	mapping.Put("a", 2)
+	if value, found := mapping.GetByKey("a"); !found || value != 2 {
+		t.Fail()
+	} else if _, found := mapping.GetByKey("b"); found {
+		t.Fail()
+	} else if _, found := mapping.GetByValue(1); found {
+		t.Fail()
+	} else if key, found := mapping.GetByValue(2); !found || key != "a" {
+		t.Fail()
+	} else if mapping.Len() != 1 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,42 @@
+package commons_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zefrenchwan/perspectives.git/commons"
+)
+
+func TestSetBasics(t *testing.T) {
+	set := commons.NewSet(1, 2, 2, 3)
+	if set.Len() != 3 {
+		t.Fail()
+	} else if !set.Contains(2) || set.Contains(4) {
+		t.Fail()
+	}
+
+	set.Add(4)
+	set.Remove(1)
+	set.Remove(10)
+	if result := slices.Sorted(slices.Values(set.Slice())); slices.Compare(result, []int{2, 3, 4}) != 0 {
+		t.Fail()
+	}
+}
+
+func TestSetOperations(t *testing.T) {
+	first := commons.NewSet(1, 2, 3)
+	second := commons.NewSet(2, 3, 4)
+
+	if result := slices.Sorted(slices.Values(first.Union(second).Slice())); slices.Compare(result, []int{1, 2, 3, 4}) != 0 {
+		t.Fail()
+	} else if result := slices.Sorted(slices.Values(first.Intersect(second).Slice())); slices.Compare(result, []int{2, 3}) != 0 {
+		t.Fail()
+	} else if result := slices.Sorted(slices.Values(first.Difference(second).Slice())); slices.Compare(result, []int{1}) != 0 {
+		t.Fail()
+	} else if first.Len() != 3 || second.Len() != 3 {
+		// operations should not change operands
+		t.Fail()
+	} else if first.Intersect(commons.NewSet[int]()).Len() != 0 {
+		t.Fail()
+	}
+}
@@ -115,3 +115,49 @@ func TestSlicesFilter(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestSliceGroupByOrdered(t *testing.T) {
+	values := []string{"abc", "d", "ef", "ghi", "j"}
+	keys, groups := commons.SliceGroupByOrdered(values, func(a string) int { return len(a) })
+	if slices.Compare(keys, []int{3, 1, 2}) != 0 {
+		t.Fail()
+	} else if !slices.Equal(groups[3], []string{"abc", "ghi"}) {
+		t.Fail()
+	} else if !slices.Equal(groups[1], []string{"d", "j"}) {
+		t.Fail()
+	} else if !slices.Equal(groups[2], []string{"ef"}) {
+		t.Fail()
+	}
+
+	if keys, groups := commons.SliceGroupByOrdered(nil, func(a string) int { return len(a) }); len(keys) != 0 || len(groups) != 0 {
+		t.Fail()
+	}
+}
+
+func TestSlicePartition(t *testing.T) {
+	even, odd := commons.SlicePartition([]int{0, 1, 2, 3, 4, 5}, func(a int) bool { return a%2 == 0 })
+	if slices.Compare(even, []int{0, 2, 4}) != 0 {
+		t.Fail()
+	} else if slices.Compare(odd, []int{1, 3, 5}) != 0 {
+		t.Fail()
+	}
+}
+
+func TestSliceChunk(t *testing.T) {
+	chunks := commons.SliceChunk([]int{1, 2, 3, 4, 5}, 2)
+	if len(chunks) != 3 {
+		t.FailNow()
+	} else if slices.Compare(chunks[0], []int{1, 2}) != 0 {
+		t.Fail()
+	} else if slices.Compare(chunks[1], []int{3, 4}) != 0 {
+		t.Fail()
+	} else if slices.Compare(chunks[2], []int{5}) != 0 {
+		t.Fail()
+	}
+
+	if commons.SliceChunk([]int{1, 2}, 0) != nil {
+		t.Fail()
+	} else if commons.SliceChunk([]int{}, 2) != nil {
+		t.Fail()
+	}
+}
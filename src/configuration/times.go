@@ -5,5 +5,6 @@ import "time"
 // TIME_FORMAT defines how to serialize and deserialize time data
 const TIME_FORMAT = time.RFC3339
 
-// TIME_PRECISION is the accepted thresold to define when two times are the same
+// TIME_PRECISION is the default accepted thresold to define when two times are the same.
+// Periods may use another one, see periods.SetTimePrecision
 const TIME_PRECISION = time.Second
@@ -0,0 +1,198 @@
+package entities
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zefrenchwan/perspectives.git/periods"
+	"github.com/zefrenchwan/perspectives.git/values"
+)
+
+// ValueChange describes the values of a name at two moments.
+// Values are sorted by serialized form.
+type ValueChange[V values.Value] struct {
+	// Old values, at the first moment
+	Old []V
+	// New values, at the second moment
+	New []V
+}
+
+// TimelineEntry is a value and the period it is valid for
+type TimelineEntry[V values.Value] struct {
+	// Value of the entry
+	Value V
+	// Period of the value
+	Period periods.Period
+}
+
+// StateDiff describes what changed for a state between two moments.
+type StateDiff[V values.Value] struct {
+	// Added contains names with no value at the first moment, but values at the second one.
+	Added []string
+	// Removed contains names with values at the first moment, but no value at the second one.
+	Removed []string
+	// Changed contains names with values at both moments, when those values differ
+	Changed map[string]ValueChange[V]
+}
+
+// DiffAttributes compares the attributes of a state at moments from and to.
+// Names in the result are sorted.
+func DiffAttributes(state State, from, to time.Time) StateDiff[values.PrimitiveValue] {
+	return diffMappings(state.Attributes(), from, to)
+}
+
+// DiffRoles compares the roles of a state at moments from and to.
+// Names in the result are sorted.
+func DiffRoles(state State, from, to time.Time) StateDiff[values.ReferenceValue] {
+	return diffMappings(state.Roles(), from, to)
+}
+
+// AttributesChangedDuring returns the sorted names of the attributes whose values change at least once during period.
+// It does not sample the period: a value is constant over period if its own period contains period or is disjoint from it.
+// So a change is detected, no matter how short it is.
+func AttributesChangedDuring(state State, period periods.Period) []string {
+	return changedDuring(state.Attributes(), period)
+}
+
+// RolesChangedDuring returns the sorted names of the roles whose values change at least once during period.
+// See AttributesChangedDuring.
+func RolesChangedDuring(state State, period periods.Period) []string {
+	return changedDuring(state.Roles(), period)
+}
+
+// AttributesAt returns the attributes of a state at moment, as names linked to their value then.
+// Attributes with no value at moment are skipped.
+// If an attribute has many values at moment (relation), the first one in serialized order is kept.
+// Result is empty if moment is outside the activity of the state.
+func AttributesAt(state State, moment time.Time) map[string]values.PrimitiveValue {
+	result := make(map[string]values.PrimitiveValue)
+	if !state.Activity().Contains(moment) {
+		return result
+	}
+
+	for name, mapping := range state.Attributes() {
+		if values := valuesAt(mapping, moment); len(values) != 0 {
+			result[name] = values[0]
+		}
+	}
+
+	return result
+}
+
+// AttributeTimeline returns the values of an attribute of a state with their periods.
+// Entries are sorted by the earliest left boundary of their period, values with no finite left boundary first.
+// Ties are sorted by serialized value.
+// It returns nil if the attribute has no value.
+func AttributeTimeline(state State, name string) []TimelineEntry[values.PrimitiveValue] {
+	for attribute, mapping := range state.Attributes() {
+		if attribute == name {
+			return timeline(mapping)
+		}
+	}
+
+	return nil
+}
+
+// timeline returns the entries of a mapping, sorted by left boundary then by serialized value
+func timeline[V values.Value](mapping values.ImmutableValuesMapping[V]) []TimelineEntry[V] {
+	if mapping == nil {
+		return nil
+	}
+
+	var result []TimelineEntry[V]
+	for period, value := range mapping.Range() {
+		if !period.IsEmpty() {
+			result = append(result, TimelineEntry[V]{Value: value, Period: period})
+		}
+	}
+
+	slices.SortStableFunc(result, func(a, b TimelineEntry[V]) int {
+		aStart, aFinite, _, _ := a.Period.Bounds()
+		bStart, bFinite, _, _ := b.Period.Bounds()
+		switch {
+		case !aFinite && bFinite:
+			return -1
+		case aFinite && !bFinite:
+			return 1
+		case aFinite && bFinite && !aStart.Equal(bStart):
+			return aStart.Compare(bStart)
+		default:
+			return strings.Compare(a.Value.Serialize(), b.Value.Serialize())
+		}
+	})
+
+	return result
+}
+
+// valuesAt returns the values active at a given moment, sorted by serialized form
+func valuesAt[V values.Value](mapping values.ImmutableValuesMapping[V], moment time.Time) []V {
+	var result []V
+	if mapping == nil {
+		return result
+	}
+
+	for period, value := range mapping.Range() {
+		if period.Contains(moment) {
+			result = append(result, value)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b V) int {
+		return strings.Compare(a.Serialize(), b.Serialize())
+	})
+
+	return result
+}
+
+// diffMappings compares values of each name at moments from and to
+func diffMappings[V values.Value](
+	content iter.Seq2[string, values.ImmutableValuesMapping[V]],
+	from, to time.Time,
+) StateDiff[V] {
+	result := StateDiff[V]{Changed: make(map[string]ValueChange[V])}
+	for name, mapping := range content {
+		before := valuesAt(mapping, from)
+		after := valuesAt(mapping, to)
+		switch {
+		case len(before) == 0 && len(after) == 0:
+			continue
+		case len(before) == 0:
+			result.Added = append(result.Added, name)
+		case len(after) == 0:
+			result.Removed = append(result.Removed, name)
+		case !slices.EqualFunc(before, after, func(a, b V) bool { return a.Equals(b) }):
+			result.Changed[name] = ValueChange[V]{Old: before, New: after}
+		}
+	}
+
+	return result
+}
+
+// changedDuring returns the sorted names whose values are not constant over period
+func changedDuring[V values.Value](
+	content iter.Seq2[string, values.ImmutableValuesMapping[V]],
+	period periods.Period,
+) []string {
+	changes := make(map[string]bool)
+	if period.IsEmpty() {
+		return nil
+	}
+
+	for name, mapping := range content {
+		if mapping == nil {
+			continue
+		}
+
+		for valuePeriod := range mapping.Range() {
+			if !valuePeriod.ContainsPeriod(period) && !valuePeriod.Intersection(period).IsEmpty() {
+				changes[name] = true
+				break
+			}
+		}
+	}
+
+	return slices.Sorted(maps.Keys(changes))
+}
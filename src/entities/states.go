@@ -2,6 +2,7 @@ package entities
 
 import (
 	"iter"
+	"maps"
 	"slices"
 	"strconv"
 	"strings"
@@ -42,6 +43,10 @@ type localState struct {
 	// roles as a map of names and related values as a mapping.
 	// Values are references to other entities.
 	roles map[string]values.ImmutableValuesMapping[values.ReferenceValue]
+	// attributeNames are the names of attributes, sorted once at creation
+	attributeNames []string
+	// roleNames are the names of roles, sorted once at creation
+	roleNames []string
 	// hashString is the hash of the state, calculated once
 	hashString string
 }
@@ -62,11 +67,12 @@ func (l localState) Activity() periods.Period {
 	return l.activity
 }
 
-// Attributes of the state as an iterator (to avoid defensive copies)
+// Attributes of the state as an iterator (to avoid defensive copies).
+// Attributes are sorted by name, so that iteration order is stable
 func (l localState) Attributes() iter.Seq2[string, values.ImmutableValuesMapping[values.PrimitiveValue]] {
 	return func(yield func(string, values.ImmutableValuesMapping[values.PrimitiveValue]) bool) {
-		for attr, mapper := range l.attributes {
-			if !yield(attr, mapper) {
+		for _, attr := range l.attributeNames {
+			if !yield(attr, l.attributes[attr]) {
 				return
 			}
 		}
@@ -75,11 +81,12 @@ func (l localState) Attributes() iter.Seq2[string, values.ImmutableValuesMapping
 	}
 }
 
-// Roles of the state as an iterator (to avoid defensive copies)
+// Roles of the state as an iterator (to avoid defensive copies).
+// Roles are sorted by name, so that iteration order is stable
 func (l localState) Roles() iter.Seq2[string, values.ImmutableValuesMapping[values.ReferenceValue]] {
 	return func(yield func(string, values.ImmutableValuesMapping[values.ReferenceValue]) bool) {
-		for role, mapper := range l.roles {
-			if !yield(role, mapper) {
+		for _, role := range l.roleNames {
+			if !yield(role, l.roles[role]) {
 				return
 			}
 		}
@@ -160,11 +167,13 @@ func NewLocalState(
 	roles map[string]values.ImmutableValuesMapping[values.ReferenceValue], // name of roles linked to immutable references
 ) State {
 	result := localState{
-		id:         id,
-		activity:   activity,
-		attributes: attributes,
-		roles:      roles,
-		hashString: "",
+		id:             id,
+		activity:       activity,
+		attributes:     attributes,
+		roles:          roles,
+		attributeNames: slices.Sorted(maps.Keys(attributes)),
+		roleNames:      slices.Sorted(maps.Keys(roles)),
+		hashString:     "",
 	}
 
 	// hash calculation once content is set
@@ -0,0 +1,182 @@
+package entities_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zefrenchwan/perspectives.git/entities"
+	"github.com/zefrenchwan/perspectives.git/periods"
+	"github.com/zefrenchwan/perspectives.git/values"
+)
+
+// buildDiffState returns a state with:
+// status flipping from on to off and back to on within [now, now + 1h]
+// name removed after now + 30 min
+// city constant
+// nickname added after now + 30 min
+func buildDiffState(t *testing.T, now time.Time) entities.State {
+	half := now.Add(30 * time.Minute)
+
+	status := values.NewPrimitiveMappingBuilder(periods.NewTimeFunction(values.PRIMITIVE_TYPE_STRING, values.EqualPrimitiveValue))
+	status.Add("on", periods.NewFullPeriod())
+	status.Add("off", periods.NewFinitePeriod(now.Add(10*time.Minute), now.Add(11*time.Minute), true, false))
+
+	name := values.NewPrimitiveMappingBuilder(periods.NewTimeFunction(values.PRIMITIVE_TYPE_STRING, values.EqualPrimitiveValue))
+	name.Add("John", periods.NewFullPeriod())
+	name.Remove(periods.NewPeriodSince(half, true))
+
+	nickname := values.NewPrimitiveMappingBuilder(periods.NewTimeFunction(values.PRIMITIVE_TYPE_STRING, values.EqualPrimitiveValue))
+	nickname.Add("Johnny", periods.NewPeriodSince(half, true))
+
+	attributes := map[string]values.ImmutableValuesMapping[values.PrimitiveValue]{
+		"city": values.NewStringLocalMapping(map[string]periods.Period{"Paris": periods.NewFullPeriod()}),
+	}
+
+	for attr, builder := range map[string]values.PrimitiveMappingBuilder{"status": status, "name": name, "nickname": nickname} {
+		if mapping, err := builder.Build(); err != nil {
+			t.Fatal(err)
+		} else {
+			attributes[attr] = mapping
+		}
+	}
+
+	return entities.NewLocalState("id", periods.NewFullPeriod(), attributes, nil)
+}
+
+func TestDiffAttributes(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	state := buildDiffState(t, now)
+
+	diff := entities.DiffAttributes(state, now, now.Add(time.Hour))
+	if !slices.Equal(diff.Added, []string{"nickname"}) {
+		t.Errorf("expected nickname added, got %v", diff.Added)
+	} else if !slices.Equal(diff.Removed, []string{"name"}) {
+		t.Errorf("expected name removed, got %v", diff.Removed)
+	} else if len(diff.Changed) != 0 {
+		t.Errorf("status is on at both moments, got changes %v", diff.Changed)
+	}
+
+	diff = entities.DiffAttributes(state, now, now.Add(10*time.Minute))
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 {
+		t.Errorf("expected no added or removed attributes, got %v", diff)
+	} else if change, found := diff.Changed["status"]; !found {
+		t.Error("status should change")
+	} else if len(change.Old) != 1 || change.Old[0].Content() != "on" {
+		t.Errorf("old value should be on, got %v", change.Old)
+	} else if len(change.New) != 1 || change.New[0].Content() != "off" {
+		t.Errorf("new value should be off, got %v", change.New)
+	} else if len(diff.Changed) != 1 {
+		t.Errorf("only status should change, got %v", diff.Changed)
+	}
+}
+
+func TestAttributesChangedDuring(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	state := buildDiffState(t, now)
+
+	// status is on at both ends, but flips twice in between: sampling on bounds would miss it
+	period := periods.NewFinitePeriod(now, now.Add(time.Hour), true, true)
+	expected := []string{"name", "nickname", "status"}
+	if changed := entities.AttributesChangedDuring(state, period); !slices.Equal(changed, expected) {
+		t.Errorf("expected %v, got %v", expected, changed)
+	}
+
+	// before any change
+	period = periods.NewFinitePeriod(now, now.Add(5*time.Minute), true, true)
+	if changed := entities.AttributesChangedDuring(state, period); len(changed) != 0 {
+		t.Errorf("expected no change, got %v", changed)
+	}
+
+	// during removal only
+	period = periods.NewFinitePeriod(now.Add(20*time.Minute), now.Add(40*time.Minute), true, true)
+	expected = []string{"name", "nickname"}
+	if changed := entities.AttributesChangedDuring(state, period); !slices.Equal(changed, expected) {
+		t.Errorf("expected %v, got %v", expected, changed)
+	}
+
+	if changed := entities.AttributesChangedDuring(state, periods.NewEmptyPeriod()); len(changed) != 0 {
+		t.Errorf("empty period cannot see changes, got %v", changed)
+	}
+}
+
+func TestAttributesAt(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	state := buildDiffState(t, now)
+
+	// contents returns the content of each value, as a string
+	contents := func(snapshot map[string]values.PrimitiveValue) map[string]string {
+		result := make(map[string]string)
+		for name, value := range snapshot {
+			result[name] = value.Content().(string)
+		}
+
+		return result
+	}
+
+	expected := map[string]string{"city": "Paris", "name": "John", "status": "on"}
+	if snapshot := contents(entities.AttributesAt(state, now)); !maps.Equal(snapshot, expected) {
+		t.Errorf("expected %v, got %v", expected, snapshot)
+	}
+
+	expected = map[string]string{"city": "Paris", "nickname": "Johnny", "status": "on"}
+	if snapshot := contents(entities.AttributesAt(state, now.Add(time.Hour))); !maps.Equal(snapshot, expected) {
+		t.Errorf("expected %v, got %v", expected, snapshot)
+	}
+
+	// same attributes, but the state is active during the first half hour only
+	attributes := make(map[string]values.ImmutableValuesMapping[values.PrimitiveValue])
+	for name, mapping := range state.Attributes() {
+		attributes[name] = mapping
+	}
+
+	activity := periods.NewFinitePeriod(now, now.Add(30*time.Minute), true, false)
+	bounded := entities.NewLocalState("bounded", activity, attributes, nil)
+	expected = map[string]string{"city": "Paris", "name": "John", "status": "off"}
+	if snapshot := contents(entities.AttributesAt(bounded, now.Add(10*time.Minute))); !maps.Equal(snapshot, expected) {
+		t.Errorf("expected %v, got %v", expected, snapshot)
+	} else if snapshot := entities.AttributesAt(bounded, now.Add(time.Hour)); snapshot == nil || len(snapshot) != 0 {
+		t.Errorf("expected an empty snapshot outside activity, got %v", snapshot)
+	}
+}
+
+func TestAttributeTimeline(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	state := buildDiffState(t, now)
+
+	// status is on, off for a minute, then on again
+	entries := entities.AttributeTimeline(state, "status")
+	if len(entries) != 2 {
+		t.Fatalf("expected one entry per value, got %v", entries)
+	} else if entries[0].Value.Content() != "on" || !entries[0].Period.Contains(now) {
+		t.Errorf("unbounded value should come first, got %v", entries[0].Value.Content())
+	} else if entries[1].Value.Content() != "off" || !entries[1].Period.Contains(now.Add(10*time.Minute)) {
+		t.Errorf("expected off last, got %v", entries[1].Value.Content())
+	}
+
+	// values are sorted by left boundary, not by addition
+	levels := values.NewPrimitiveMappingBuilder(periods.NewTimeFunction(values.PRIMITIVE_TYPE_STRING, values.EqualPrimitiveValue))
+	levels.Add("high", periods.NewPeriodSince(now.Add(2*time.Hour), true))
+	levels.Add("low", periods.NewFinitePeriod(now, now.Add(time.Hour), true, false))
+	levels.Add("medium", periods.NewFinitePeriod(now.Add(time.Hour), now.Add(2*time.Hour), true, false))
+	mapping, err := levels.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attributes := map[string]values.ImmutableValuesMapping[values.PrimitiveValue]{"level": mapping}
+	other := entities.NewLocalState("other", periods.NewFullPeriod(), attributes, nil)
+	var contents []any
+	for _, entry := range entities.AttributeTimeline(other, "level") {
+		contents = append(contents, entry.Value.Content())
+	}
+
+	if expected := []any{"low", "medium", "high"}; !slices.Equal(contents, expected) {
+		t.Errorf("expected %v, got %v", expected, contents)
+	}
+
+	if entries := entities.AttributeTimeline(state, "unknown"); entries != nil {
+		t.Errorf("unknown attribute should have no timeline, got %v", entries)
+	}
+}
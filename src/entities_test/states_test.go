@@ -1,6 +1,7 @@
 package entities_test
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -82,3 +83,31 @@ func TestLocalStateHash(t *testing.T) {
 		t.Error("wrong hash : attributes state should not be equal to role state")
 	}
 }
+
+func TestLocalStateStableOrder(t *testing.T) {
+	attributes := make(map[string]values.ImmutableValuesMapping[values.PrimitiveValue])
+	roles := make(map[string]values.ImmutableValuesMapping[values.ReferenceValue])
+	for _, name := range []string{"e", "b", "d", "a", "c"} {
+		attributes[name] = values.NewStringLocalMapping(map[string]periods.Period{name: periods.NewFullPeriod()})
+		roles[name] = values.NewReferenceLocalMapping(map[string]periods.Period{name: periods.NewFullPeriod()})
+	}
+
+	state := entities.NewLocalState("id", periods.NewFullPeriod(), attributes, roles)
+	expected := []string{"a", "b", "c", "d", "e"}
+	for range 20 {
+		var attributeNames, roleNames []string
+		for name := range state.Attributes() {
+			attributeNames = append(attributeNames, name)
+		}
+
+		for name := range state.Roles() {
+			roleNames = append(roleNames, name)
+		}
+
+		if !slices.Equal(attributeNames, expected) {
+			t.Errorf("attributes should be sorted by name, got %v", attributeNames)
+		} else if !slices.Equal(roleNames, expected) {
+			t.Errorf("roles should be sorted by name, got %v", roleNames)
+		}
+	}
+}
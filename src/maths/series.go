@@ -1,8 +1,12 @@
 package maths
 
 import (
+	"cmp"
 	"errors"
+	"iter"
+	"maps"
 	"math"
+	"slices"
 )
 
 // Serie is a generic interface representing a sequence of floating-point numbers.
@@ -12,8 +16,12 @@ type Serie[F FloatNumber] interface {
 	// Returns true if both have the same size and all elements are equal
 	// based on the floating-point precision logic.
 	Equals(other Serie[F]) bool
+	// EqualsWithin checks if both series have the same size and values differ by at most tolerance.
+	EqualsWithin(other Serie[F], tolerance F) bool
 	// Size returns the total number of elements in the series.
 	Size() int
+	// DefaultValue returns the value of indexes that were not set.
+	DefaultValue() F
 	// Values returns the full sequence of values as a slice.
 	Values() []F
 	// Set assigns a value at the specified index.
@@ -29,6 +37,32 @@ type Serie[F FloatNumber] interface {
 	Cut(from, to int) (Serie[F], error)
 	// Indicators returns the mean and standard deviation of the series.
 	Indicators() (mean, stddev float64)
+	// Rolling returns the mean and standard deviation over each window of consecutive values.
+	// Results have the same size as the series, value at index i covers [i-window+1, i].
+	// First window-1 values are NaN. Returns an error if window is not positive or more than the size.
+	Rolling(window int) (Serie[F], Serie[F], error)
+	// Min returns the minimum value of the series, false if the series is empty.
+	Min() (F, bool)
+	// Max returns the maximum value of the series, false if the series is empty.
+	Max() (F, bool)
+	// WeightedMean returns the mean of the series, each value weighted by the value of weights at the same index.
+	// Returns an error if sizes differ or if weights sum to zero.
+	WeightedMean(weights Serie[F]) (float64, error)
+	// CDF returns the cumulative distribution of the series: distinct values, sorted,
+	// with the fraction of values less than or equal to each of them.
+	// Returns an error if the series is empty.
+	CDF() ([]CumulativeValue[F], error)
+	// InterpolateAt returns the value at position x, linearly interpolated between the two nearest indexes.
+	// Returns false if x is not in [0, size-1].
+	InterpolateAt(x float64) (F, bool)
+}
+
+// CumulativeValue is a point of a cumulative distribution.
+type CumulativeValue[F FloatNumber] struct {
+	// Value is a value of the series.
+	Value F
+	// Cumulative is the fraction of values less than or equal to Value, between 0 and 1.
+	Cumulative float64
 }
 
 // localSerie is a memory-efficient implementation of the Serie interface.
@@ -71,12 +105,40 @@ func (l *localSerie[F]) Equals(other Serie[F]) bool {
 	return true
 }
 
+// EqualsWithin compares two series element-wise with an explicit tolerance.
+// Complexity: O(N) where N is the size of the series.
+func (l *localSerie[F]) EqualsWithin(other Serie[F], tolerance F) bool {
+	if l == nil && other == nil {
+		return true
+	} else if l == nil || other == nil {
+		return false
+	} else if other.Size() != l.size {
+		return false
+	}
+
+	for i := 0; i < l.size; i++ {
+		valA, _ := l.Get(i)
+		valB, _ := other.Get(i)
+		if math.Abs(float64(valA)-float64(valB)) > float64(tolerance) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Size returns the current logical length of the series.
 // Complexity: O(1).
 func (l *localSerie[F]) Size() int {
 	return l.size
 }
 
+// DefaultValue returns the value of indexes that were not set.
+// Complexity: O(1).
+func (l *localSerie[F]) DefaultValue() F {
+	return l.defaultValue
+}
+
 // Values materializes the series into a slice of type F.
 // Complexity: O(N) where N is the size of the series.
 // Implementation choice: It pre-allocates the slice to avoid multiple reallocations during the loop.
@@ -184,12 +246,183 @@ func (l *localSerie[F]) Indicators() (mean, stddev float64) {
 		return math.NaN(), math.NaN()
 	}
 
+	return sparseIndicators(maps.Values(l.values), l.size, l.defaultValue)
+}
+
+// Rolling calculates the population mean and standard deviation for each window of consecutive values.
+//
+// Implementation choice: a window with default values only has the default value as mean and 0 as
+// standard deviation, which are the default values of the resulting series. So only windows containing
+// a stored value are calculated, each one with the batched update of Indicators.
+// Complexity: O(V * window * log V) where V is the number of stored values, whatever the size of the series.
+func (l *localSerie[F]) Rolling(window int) (Serie[F], Serie[F], error) {
+	if window <= 0 || window > l.size {
+		return nil, nil, errors.New("invalid window")
+	}
+
+	means := newLocalSerie(l.size, l.defaultValue)
+	stddevs := newLocalSerie(l.size, F(0))
+	// first windows are incomplete
+	notANumber := F(math.NaN())
+	for index := 0; index < window-1; index++ {
+		means.Set(index, notANumber)
+		stddevs.Set(index, notANumber)
+	}
+
+	indexes := slices.Sorted(maps.Keys(l.values))
+	// next is the first window end not calculated yet
+	next := window - 1
+	for _, storedIndex := range indexes {
+		// windows containing storedIndex end between storedIndex and storedIndex + window - 1
+		from, to := max(storedIndex, next), min(storedIndex+window-1, l.size-1)
+		for end := from; end <= to; end++ {
+			low, _ := slices.BinarySearch(indexes, end-window+1)
+			high, _ := slices.BinarySearch(indexes, end+1)
+			stored := func(yield func(F) bool) {
+				for _, index := range indexes[low:high] {
+					if !yield(l.values[index]) {
+						return
+					}
+				}
+			}
+
+			mean, stddev := sparseIndicators(stored, window, l.defaultValue)
+			means.Set(end, F(mean))
+			stddevs.Set(end, F(stddev))
+		}
+
+		next = max(next, to+1)
+	}
+
+	return means, stddevs, nil
+}
+
+// Min returns the minimum value of the series.
+// Complexity: O(V), default value counts only if at least one index is not stored.
+func (l *localSerie[F]) Min() (F, bool) {
+	return l.extremum(func(a, b F) bool { return a < b })
+}
+
+// Max returns the maximum value of the series.
+// Complexity: O(V), default value counts only if at least one index is not stored.
+func (l *localSerie[F]) Max() (F, bool) {
+	return l.extremum(func(a, b F) bool { return a > b })
+}
+
+// InterpolateAt returns the linear interpolation of values at floor(x) and ceil(x).
+// Complexity: O(1) average.
+func (l *localSerie[F]) InterpolateAt(x float64) (F, bool) {
+	if l == nil || math.IsNaN(x) || x < 0 || x > float64(l.size-1) {
+		var zero F
+		return zero, false
+	}
+
+	lower := int(math.Floor(x))
+	lowerValue, _ := l.Get(lower)
+	if float64(lower) == x {
+		return lowerValue, true
+	}
+
+	upperValue, _ := l.Get(lower + 1)
+	weight := x - float64(lower)
+	return F(float64(lowerValue) + weight*(float64(upperValue)-float64(lowerValue))), true
+}
+
+// WeightedMean returns sum(value * weight) / sum(weight).
+// Complexity: O(N) where N is the size of the series, weights may not be sparse.
+func (l *localSerie[F]) WeightedMean(weights Serie[F]) (float64, error) {
+	if l == nil || weights == nil {
+		return math.NaN(), errors.New("nil serie")
+	} else if weights.Size() != l.size {
+		return math.NaN(), errors.New("sizes are not equal")
+	}
+
+	sum, totalWeight := 0.0, 0.0
+	for index := 0; index < l.size; index++ {
+		value, _ := l.Get(index)
+		weight, _ := weights.Get(index)
+		sum += float64(value) * float64(weight)
+		totalWeight += float64(weight)
+	}
+
+	if equalsFloat64(totalWeight, 0.0) {
+		return math.NaN(), errors.New("weights sum to zero")
+	}
+
+	return sum / totalWeight, nil
+}
+
+// CDF returns the cumulative distribution of the series.
+// Complexity: O(V log V), all default values are counted at once.
+// Values equal within epsilon are considered the same value (the smallest one is kept).
+func (l *localSerie[F]) CDF() ([]CumulativeValue[F], error) {
+	if l == nil || l.size == 0 {
+		return nil, errors.New("empty serie")
+	}
+
+	// counted values: each stored value once, default value for all remaining indexes
+	type countedValue struct {
+		value F
+		count int
+	}
+
+	counted := make([]countedValue, 0, len(l.values)+1)
+	for _, value := range l.values {
+		counted = append(counted, countedValue{value: value, count: 1})
+	}
+
+	if defaults := l.size - len(l.values); defaults > 0 {
+		counted = append(counted, countedValue{value: l.defaultValue, count: defaults})
+	}
+
+	slices.SortFunc(counted, func(a, b countedValue) int { return cmp.Compare(a.value, b.value) })
+
+	var result []CumulativeValue[F]
+	cumulated := 0
+	for _, element := range counted {
+		cumulated += element.count
+		cumulative := float64(cumulated) / float64(l.size)
+		if last := len(result) - 1; last >= 0 && l.equality(result[last].Value, element.value) {
+			result[last].Cumulative = cumulative
+		} else {
+			result = append(result, CumulativeValue[F]{Value: element.value, Cumulative: cumulative})
+		}
+	}
+
+	return result, nil
+}
+
+// extremum returns the value v such that better(value, v) is false for any value of the series
+func (l *localSerie[F]) extremum(better func(a, b F) bool) (F, bool) {
+	var result F
+	if l == nil || l.size == 0 {
+		return result, false
+	}
+
+	found := false
+	if len(l.values) < l.size {
+		result, found = l.defaultValue, true
+	}
+
+	for _, value := range l.values {
+		if !found || better(value, result) {
+			result, found = value, true
+		}
+	}
+
+	return result, true
+}
+
+// sparseIndicators returns the population mean and standard deviation of size values:
+// stored ones, then as many default values as needed to reach size.
+// See Indicators for the algorithm. It expects size to be positive.
+func sparseIndicators[F FloatNumber](stored iter.Seq[F], size int, defaultValue F) (mean, stddev float64) {
 	count := 0
 	mean = 0.0
 	M2 := 0.0 // Sum of squares of differences from the current mean
 
 	// 1. Standard Welford's algorithm for explicitly defined values in the sparse map.
-	for _, value := range l.values {
+	for value := range stored {
 		count++
 		v := float64(value)
 		delta := v - mean
@@ -201,9 +434,9 @@ func (l *localSerie[F]) Indicators() (mean, stddev float64) {
 	// 2. Batched Welford update for the remaining implicit default values.
 	// This avoids looping over potentially millions of default values,
 	// preserving the O(V) performance characteristic of the sparse series.
-	remaining := l.size - len(l.values)
+	remaining := size - count
 	if remaining > 0 {
-		v := float64(l.defaultValue)
+		v := float64(defaultValue)
 		if count == 0 {
 			// Fast path: if the series entirely consists of default values,
 			// the mean is exactly the default value and the variance is 0.
@@ -229,7 +462,7 @@ func (l *localSerie[F]) Indicators() (mean, stddev float64) {
 
 	// Calculate the population variance (M2 / N).
 	// Note: If sample variance were needed, the divisor would be (N - 1).
-	variance := M2 / float64(l.size)
+	variance := M2 / float64(size)
 
 	// Safeguard against floating-point inaccuracies that could rarely produce
 	// an infinitesimally small negative variance (e.g., -1e-16).
@@ -269,6 +502,70 @@ func NewSerie[F FloatNumber](size int, defaultValue F) Serie[F] {
 	return newLocalSerie(size, defaultValue)
 }
 
+// Resample returns a new serie of newSize values, linearly interpolated from s.
+// Index j of the result matches position j * (s.Size() - 1) / (newSize - 1) in s, so first and last values are kept.
+// Stored and default values are interpolated the same way, result has the same default value as s.
+// SPECIAL CASES: newSize 0 returns an empty serie, newSize 1 returns the first value of s.
+// Returns an error if newSize is negative, or if s is empty and newSize is not.
+func Resample[F FloatNumber](s Serie[F], newSize int) (Serie[F], error) {
+	if s == nil || newSize < 0 {
+		return nil, errors.New("invalid resample parameters")
+	}
+
+	size := s.Size()
+	result := newLocalSerie(newSize, s.DefaultValue())
+	if newSize == 0 {
+		return result, nil
+	} else if size == 0 {
+		return nil, errors.New("cannot resample an empty serie")
+	} else if newSize == size {
+		return serieCopy(s, size), nil
+	} else if newSize == 1 || size == 1 {
+		// no interpolation possible: first value only
+		first, _ := s.Get(0)
+		for index := 0; index < newSize; index++ {
+			result.Set(index, first)
+		}
+
+		return result, nil
+	}
+
+	ratio := float64(size-1) / float64(newSize-1)
+	for index := 0; index < newSize; index++ {
+		position := float64(index) * ratio
+		lower := min(int(math.Floor(position)), size-1)
+		upper := min(lower+1, size-1)
+		lowerValue, _ := s.Get(lower)
+		upperValue, _ := s.Get(upper)
+		weight := position - float64(lower)
+		result.Set(index, F(float64(lowerValue)+weight*(float64(upperValue)-float64(lowerValue))))
+	}
+
+	return result, nil
+}
+
+// Align returns copies of a and b with the same size: the shortest one is padded with its default value.
+// Returns an error if a serie is nil.
+func Align[F FloatNumber](a, b Serie[F]) (Serie[F], Serie[F], error) {
+	if a == nil || b == nil {
+		return nil, nil, errors.New("nil serie")
+	}
+
+	size := max(a.Size(), b.Size())
+	return serieCopy(a, size), serieCopy(b, size), nil
+}
+
+// serieCopy returns a copy of s with size values, indexes after s size have the default value of s
+func serieCopy[F FloatNumber](s Serie[F], size int) *localSerie[F] {
+	result := newLocalSerie(size, s.DefaultValue())
+	for index := 0; index < s.Size(); index++ {
+		value, _ := s.Get(index)
+		result.Set(index, value)
+	}
+
+	return result
+}
+
 // NewEmptySerie returns a new empty serie with the default value to set
 func NewEmptySerie[F FloatNumber](defaultValue F) Serie[F] {
 	return NewSerie(0, defaultValue)
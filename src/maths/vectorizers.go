@@ -0,0 +1,86 @@
+package maths
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// VectorizerRegistry stores vectorizers by name, to pick one at runtime.
+type VectorizerRegistry struct {
+	// vectorizers by name
+	vectorizers map[string]Vectorizer
+}
+
+// NewVectorizerRegistry returns an empty registry
+func NewVectorizerRegistry() *VectorizerRegistry {
+	return &VectorizerRegistry{vectorizers: make(map[string]Vectorizer)}
+}
+
+// Register sets the vectorizer for that name, replacing any previous one.
+// Returns an error for a nil vectorizer.
+func (r *VectorizerRegistry) Register(name string, v Vectorizer) error {
+	if v == nil {
+		return errors.New("nil vectorizer")
+	}
+
+	r.vectorizers[name] = v
+	return nil
+}
+
+// Vectorize applies the vectorizer registered as name to value.
+// Returns an error if no vectorizer matches that name, or if vectorization fails.
+func (r *VectorizerRegistry) Vectorize(name string, value any) (ColumnMatrix, error) {
+	vectorizer, found := r.vectorizers[name]
+	if !found {
+		return nil, fmt.Errorf("no vectorizer for %s", name)
+	}
+
+	return vectorizer(value)
+}
+
+// NewAttributesVectorizer returns a vectorizer for map[string]float64 values (attribute name to value).
+// Vector has one component per attribute, attributes sorted by name, so insertion order does not matter.
+// Missing attributes map to 0, or raise an error if missingAsError is true.
+// Values of other types raise an error.
+func NewAttributesVectorizer(attributes []string, missingAsError bool) Vectorizer {
+	names := slices.Clone(attributes)
+	slices.Sort(names)
+	names = slices.Compact(names)
+
+	return func(value any) (ColumnMatrix, error) {
+		values, ok := value.(map[string]float64)
+		if !ok {
+			return nil, errors.New("expecting map[string]float64 values")
+		}
+
+		result := make([]float64, len(names))
+		for index, name := range names {
+			if attributeValue, found := values[name]; found {
+				result[index] = attributeValue
+			} else if missingAsError {
+				return nil, fmt.Errorf("missing attribute %s", name)
+			}
+		}
+
+		return denseColumnMatrix(result), nil
+	}
+}
+
+// ComposeVectorizers returns a vectorizer that concatenates the vectors of each vectorizer, in order.
+// If one of them fails, the composed vectorizer fails.
+func ComposeVectorizers(vectorizers ...Vectorizer) Vectorizer {
+	return func(value any) (ColumnMatrix, error) {
+		var result []float64
+		for index, vectorizer := range vectorizers {
+			vector, err := vectorizer(value)
+			if err != nil {
+				return nil, fmt.Errorf("vectorizer %d failed: %w", index, err)
+			}
+
+			result = append(result, vector.Export()...)
+		}
+
+		return NewColumnMatrix(result), nil
+	}
+}
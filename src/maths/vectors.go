@@ -21,14 +21,24 @@ type ColumnMatrix interface {
 	// DotProduct computes the dot product of this vector and another ColumnMatrix.
 	// Returns the resulting number or an error if dimensions do not match.
 	DotProduct(ColumnMatrix) (float64, error)
-	// Norm returns the norm of that vector, that is the sum of its squared components)
+	// Dot is an alias of DotProduct.
+	Dot(ColumnMatrix) (float64, error)
+	// Sub subtracts another ColumnMatrix of the same size from this one.
+	// Returns the resulting ColumnMatrix or an error if dimensions do not match.
+	Sub(ColumnMatrix) (ColumnMatrix, error)
+	// Norm returns the euclidean (L2) norm of that vector, that is the square root of the sum of its squared components
 	Norm() float64
+	// Normalize returns that vector divided by its norm.
+	// Returns an error for the zero vector.
+	Normalize() (ColumnMatrix, error)
 	// Multiply returns that vector multiplied by a scalar
 	Multiply(scalar float64) ColumnMatrix
+	// Scale is an alias of Multiply.
+	Scale(factor float64) ColumnMatrix
 	// Equals checks if this matrix is equal to another ColumnMatrix.
 	// Returns true if both have the same size and contain the same values.
 	Equals(ColumnMatrix) bool
-	// Export returns the matrix content as a slice of float64.
+	// Export returns a copy of the matrix content as a slice of float64.
 	Export() []float64
 	// Size returns the number of rows in the column matrix.
 	Size() int
@@ -45,6 +55,11 @@ type SquareMatrix interface {
 	// Multiply multiplies this square matrix by a ColumnMatrix (vector).
 	// Returns the resulting ColumnMatrix or an error if dimensions do not match.
 	Multiply(ColumnMatrix) (ColumnMatrix, error)
+	// MultiplyMatrix computes the matrix product of this matrix and another SquareMatrix of the same size.
+	// Returns the resulting SquareMatrix or an error if dimensions do not match.
+	MultiplyMatrix(SquareMatrix) (SquareMatrix, error)
+	// Transpose returns a new matrix whose rows are the columns of this one.
+	Transpose() SquareMatrix
 	// Export returns the matrix content as a 2D slice of float64.
 	Export() [][]float64
 	// Row returns the row at the specified index as a slice of float64.
@@ -60,9 +75,22 @@ type SquareMatrix interface {
 // denseColumnMatrix is an implementation of ColumnMatrix using a slice of float64.
 type denseColumnMatrix []float64
 
-// Export returns the raw content of the matrix as a slice of float64.
+// Export returns a copy of the content of the matrix as a slice of float64.
+// Changing the result does not change the matrix.
 func (c denseColumnMatrix) Export() []float64 {
-	return c
+	result := make([]float64, len(c))
+	copy(result, c)
+	return result
+}
+
+// columnValues returns the values of a column matrix, without copy if possible.
+// Result is read only.
+func columnValues(d ColumnMatrix) []float64 {
+	if dense, ok := d.(denseColumnMatrix); ok {
+		return dense
+	}
+
+	return d.Export()
 }
 
 // Size returns the number of elements (rows) in the column matrix.
@@ -74,7 +102,7 @@ func (c denseColumnMatrix) Size() int {
 // It returns a new ColumnMatrix containing the sum.
 // Returns an error if the sizes of the two matrices do not match.
 func (c denseColumnMatrix) Add(d ColumnMatrix) (ColumnMatrix, error) {
-	other := d.Export()
+	other := columnValues(d)
 	if len(c) != len(other) {
 		return nil, errors.New("dimensions are not equal")
 	}
@@ -91,7 +119,7 @@ func (c denseColumnMatrix) Add(d ColumnMatrix) (ColumnMatrix, error) {
 // Result is sum of c[index] * d[index] for index in range c.
 // Returns an error if the sizes of the two matrices do not match.
 func (c denseColumnMatrix) DotProduct(d ColumnMatrix) (float64, error) {
-	other := d.Export()
+	other := columnValues(d)
 	if len(c) != len(other) {
 		return 0.0, errors.New("dimensions are not equal")
 	}
@@ -108,7 +136,7 @@ func (c denseColumnMatrix) DotProduct(d ColumnMatrix) (float64, error) {
 // The result is a SquareMatrix where result[i][j] = c[i] * other[j].
 // Returns an error if the sizes do not match.
 func (c denseColumnMatrix) ExternalProduct(other ColumnMatrix) (SquareMatrix, error) {
-	d := other.Export()
+	d := columnValues(other)
 	if len(c) != len(d) {
 		return nil, errors.New("dimensions are not equal")
 	} else if len(c) == 0 {
@@ -131,14 +159,47 @@ func (c denseColumnMatrix) ExternalProduct(other ColumnMatrix) (SquareMatrix, er
 	return result, nil
 }
 
-// Norm returns the norm of that vector, that is the sum of its squared components
+// Sub subtracts another ColumnMatrix from this one element-wise.
+// It returns a new ColumnMatrix containing the difference.
+// Returns an error if the sizes of the two matrices do not match.
+func (c denseColumnMatrix) Sub(d ColumnMatrix) (ColumnMatrix, error) {
+	other := columnValues(d)
+	if len(c) != len(other) {
+		return nil, errors.New("dimensions are not equal")
+	}
+
+	result := make(denseColumnMatrix, len(c))
+	for index := 0; index < len(other); index++ {
+		result[index] = c[index] - other[index]
+	}
+
+	return result, nil
+}
+
+// Dot returns the scalar product of a column matrix with that same size, as DotProduct does.
+func (c denseColumnMatrix) Dot(d ColumnMatrix) (float64, error) {
+	return c.DotProduct(d)
+}
+
+// Norm returns the euclidean norm of that vector, that is the square root of the sum of its squared components
 func (c denseColumnMatrix) Norm() float64 {
 	result := 0.0
 	for _, value := range c {
-		result += math.Pow(value, 2)
+		result += value * value
 	}
 
-	return result
+	return math.Sqrt(result)
+}
+
+// Normalize returns a new vector with the same direction and a norm of 1.
+// Returns an error if the vector is the zero vector (including the empty vector).
+func (c denseColumnMatrix) Normalize() (ColumnMatrix, error) {
+	norm := c.Norm()
+	if equalsFloats(norm, 0.0) {
+		return nil, errors.New("cannot normalize zero vector")
+	}
+
+	return c.Multiply(1.0 / norm), nil
 }
 
 // Multiply returns that vector multiplied by a scalar
@@ -151,10 +212,15 @@ func (c denseColumnMatrix) Multiply(scalar float64) ColumnMatrix {
 	return denseColumnMatrix(result)
 }
 
+// Scale returns that vector multiplied by a factor, as Multiply does.
+func (c denseColumnMatrix) Scale(factor float64) ColumnMatrix {
+	return c.Multiply(factor)
+}
+
 // Equals compares this matrix with another ColumnMatrix for equality.
 // Two matrices are equal if they have the same size and all corresponding elements are equal.
 func (c denseColumnMatrix) Equals(other ColumnMatrix) bool {
-	d := other.Export()
+	d := columnValues(other)
 	if len(c) != len(d) {
 		return false
 	} else if len(c) == 0 || len(d) == 0 {
@@ -218,7 +284,7 @@ func (s denseSquareMatrix) Add(other SquareMatrix) (SquareMatrix, error) {
 // The result is a ColumnMatrix representing the matrix-vector product.
 // Returns an error if the matrix is empty or if dimensions are incompatible.
 func (s denseSquareMatrix) Multiply(other ColumnMatrix) (ColumnMatrix, error) {
-	c := other.Export()
+	c := columnValues(other)
 	size := len(c)
 
 	if len(s) != size {
@@ -242,6 +308,51 @@ func (s denseSquareMatrix) Multiply(other ColumnMatrix) (ColumnMatrix, error) {
 	return denseColumnMatrix(result), nil
 }
 
+// MultiplyMatrix computes the product of this square matrix and another one.
+// Loops are ordered (row, inner, column) so that both matrices are read row by row,
+// which is far more cache friendly than the naive (row, column, inner) ordering.
+// Returns an error if dimensions do not match.
+func (s denseSquareMatrix) MultiplyMatrix(other SquareMatrix) (SquareMatrix, error) {
+	d := other.Export()
+	size := len(s)
+	if len(d) != size {
+		return nil, errors.New("dimensions are not equal")
+	}
+
+	for index := 0; index < size; index++ {
+		if len(s[index]) != size || len(d[index]) != size {
+			return nil, errors.New("invalid matrix size")
+		}
+	}
+
+	result := newDenseSquareMatrix(size)
+	for i := 0; i < size; i++ {
+		row := result[i]
+		for k := 0; k < size; k++ {
+			// no shortcut on zero factors: 0 * Inf and 0 * NaN are NaN
+			factor := s[i][k]
+			for j, value := range d[k] {
+				row[j] += factor * value
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Transpose returns a new matrix where result[i][j] = s[j][i].
+func (s denseSquareMatrix) Transpose() SquareMatrix {
+	size := len(s)
+	result := newDenseSquareMatrix(size)
+	for i := 0; i < size; i++ {
+		for j := 0; j < size; j++ {
+			result[j][i] = s[i][j]
+		}
+	}
+
+	return result
+}
+
 // Equals compares this matrix with another SquareMatrix for equality.
 // Two matrices are equal if they have the same size and all corresponding elements are equal.
 func (s denseSquareMatrix) Equals(other SquareMatrix) bool {
@@ -303,6 +414,40 @@ func (s denseSquareMatrix) Column(index int) ([]float64, error) {
 	return result, nil
 }
 
+// newDenseSquareMatrix allocates a zero matrix of that size.
+// Rows share a single contiguous block for better cache locality.
+func newDenseSquareMatrix(size int) denseSquareMatrix {
+	result := make(denseSquareMatrix, size)
+	data := make([]float64, size*size)
+	for index := 0; index < size; index++ {
+		result[index] = data[index*size : (index+1)*size]
+	}
+
+	return result
+}
+
+// NewIdentityMatrix returns the identity matrix of that size.
+// A non positive size returns an empty matrix.
+func NewIdentityMatrix(size int) SquareMatrix {
+	result := newDenseSquareMatrix(max(size, 0))
+	for index := range result {
+		result[index][index] = 1.0
+	}
+
+	return result
+}
+
+// NewDiagonalMatrix returns a matrix with values on its diagonal and zeros elsewhere.
+// Its size is the number of values.
+func NewDiagonalMatrix(values []float64) SquareMatrix {
+	result := newDenseSquareMatrix(len(values))
+	for index, value := range values {
+		result[index][index] = value
+	}
+
+	return result
+}
+
 // NewSquareMatrix creates a new SquareMatrix from a 2D slice of float64 values.
 // It validates that the input is a square matrix of the specified size.
 // Returns an error if the input dimensions do not match the specified size.
@@ -240,3 +240,281 @@ func TestSerie_Indicators(t *testing.T) {
 		t.Errorf("Expected stddev 2.0, got %f", stddev)
 	}
 }
+
+// bruteForceRolling calculates rolling mean and stddev index per index
+func bruteForceRolling(values []float64, window int) ([]float64, []float64) {
+	means := make([]float64, len(values))
+	stddevs := make([]float64, len(values))
+	for end := range values {
+		if end < window-1 {
+			means[end], stddevs[end] = math.NaN(), math.NaN()
+			continue
+		}
+
+		sum := 0.0
+		for _, value := range values[end-window+1 : end+1] {
+			sum += value
+		}
+
+		mean := sum / float64(window)
+		variance := 0.0
+		for _, value := range values[end-window+1 : end+1] {
+			variance += (value - mean) * (value - mean)
+		}
+
+		means[end], stddevs[end] = mean, math.Sqrt(variance/float64(window))
+	}
+
+	return means, stddevs
+}
+
+func TestSerie_Rolling(t *testing.T) {
+	s := maths.NewSerie(20, 1.0)
+	s.Set(3, 5.0)
+	s.Set(4, -2.0)
+	s.Set(12, 8.0)
+	s.Set(19, 3.0)
+
+	for _, window := range []int{1, 2, 3, 7, 20} {
+		means, stddevs, err := s.Rolling(window)
+		if err != nil {
+			t.Fatalf("unexpected error for window %d: %v", window, err)
+		} else if means.Size() != s.Size() || stddevs.Size() != s.Size() {
+			t.Fatalf("rolling series should have the same size, window %d", window)
+		}
+
+		expectedMeans, expectedStddevs := bruteForceRolling(s.Values(), window)
+		for index := range expectedMeans {
+			mean, _ := means.Get(index)
+			stddev, _ := stddevs.Get(index)
+			if math.IsNaN(expectedMeans[index]) {
+				if !math.IsNaN(mean) || !math.IsNaN(stddev) {
+					t.Errorf("window %d index %d: expected NaN, got %f and %f", window, index, mean, stddev)
+				}
+			} else if math.Abs(mean-expectedMeans[index]) > 1e-9 || math.Abs(stddev-expectedStddevs[index]) > 1e-9 {
+				t.Errorf("window %d index %d: expected %f and %f, got %f and %f", window, index, expectedMeans[index], expectedStddevs[index], mean, stddev)
+			}
+		}
+	}
+
+	if _, _, err := s.Rolling(0); err == nil {
+		t.Error("expected error for zero window")
+	} else if _, _, err := s.Rolling(21); err == nil {
+		t.Error("expected error for window larger than the serie")
+	}
+}
+
+func TestSerie_RollingSparse(t *testing.T) {
+	// millions of default values and a handful of stored points
+	s := maths.NewSerie(5_000_000, 0.0)
+	s.Set(10, 4.0)
+	s.Set(4_000_000, 8.0)
+
+	means, _, err := s.Rolling(4)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if value, _ := means.Get(12); value != 1.0 {
+		t.Errorf("expected mean 1.0, got %f", value)
+	} else if value, _ := means.Get(4_000_003); value != 2.0 {
+		t.Errorf("expected mean 2.0, got %f", value)
+	} else if value, _ := means.Get(3_000_000); value != 0.0 {
+		t.Errorf("expected default mean, got %f", value)
+	}
+}
+
+func TestSerie_MinMax(t *testing.T) {
+	if _, found := maths.NewEmptySerie(0.0).Min(); found {
+		t.Error("empty serie has no minimum")
+	} else if _, found := maths.NewEmptySerie(0.0).Max(); found {
+		t.Error("empty serie has no maximum")
+	}
+
+	s := maths.NewSerie(5, 2.0)
+	s.Set(1, 7.0)
+	s.Set(3, 4.0)
+	if value, found := s.Min(); !found || value != 2.0 {
+		t.Errorf("expected default as minimum, got %f", value)
+	} else if value, found := s.Max(); !found || value != 7.0 {
+		t.Errorf("expected maximum 7.0, got %f", value)
+	}
+
+	// every index stored: default value does not count
+	s = maths.NewSerie(0, 2.0)
+	s.Append(5.0)
+	s.Append(3.0)
+	if value, found := s.Min(); !found || value != 3.0 {
+		t.Errorf("expected minimum 3.0, got %f", value)
+	}
+}
+
+func TestSerie_CDF(t *testing.T) {
+	if _, err := maths.NewEmptySerie(0.0).CDF(); err == nil {
+		t.Error("expected error for empty serie")
+	}
+
+	// 1, 3, 1, 2, 1 : 1 -> 0.6, 2 -> 0.8, 3 -> 1.0
+	s := maths.NewSerie(5, 1.0)
+	s.Set(1, 3.0)
+	s.Set(3, 2.0)
+	result, err := s.CDF()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if len(result) != 3 {
+		t.Fatalf("expected 3 distinct values, got %d", len(result))
+	}
+
+	expectedValues := []float64{1.0, 2.0, 3.0}
+	expectedCumulatives := []float64{0.6, 0.8, 1.0}
+	for index, element := range result {
+		if element.Value != expectedValues[index] || math.Abs(element.Cumulative-expectedCumulatives[index]) > 1e-9 {
+			t.Errorf("index %d: expected %f -> %f, got %f -> %f", index, expectedValues[index], expectedCumulatives[index], element.Value, element.Cumulative)
+		}
+	}
+
+	if last := result[len(result)-1]; last.Cumulative != 1.0 {
+		t.Errorf("last cumulative should be 1.0, got %f", last.Cumulative)
+	}
+}
+
+func TestSerie_WeightedMean(t *testing.T) {
+	s := maths.NewSerie(4, 0.0)
+	s.Set(0, 2.0)
+	s.Set(1, 4.0)
+	s.Set(2, 6.0)
+	s.Set(3, 8.0)
+
+	// uniform weights: plain mean
+	mean, _ := s.Indicators()
+	if result, err := s.WeightedMean(maths.NewSerie(4, 3.0)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if math.Abs(result-mean) > 1e-9 {
+		t.Errorf("expected %f, got %f", mean, result)
+	}
+
+	// skewed weights: (2*1 + 8*3) / 4 = 6.5
+	weights := maths.NewSerie(4, 0.0)
+	weights.Set(0, 1.0)
+	weights.Set(3, 3.0)
+	if result, err := s.WeightedMean(weights); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if math.Abs(result-6.5) > 1e-9 {
+		t.Errorf("expected 6.5, got %f", result)
+	}
+
+	if _, err := s.WeightedMean(maths.NewSerie(3, 1.0)); err == nil {
+		t.Error("expected error for different sizes")
+	} else if _, err := s.WeightedMean(maths.NewSerie(4, 0.0)); err == nil {
+		t.Error("expected error for zero weights")
+	}
+}
+
+func TestResample(t *testing.T) {
+	// 0, 10, 20 resampled to 5 values: 0, 5, 10, 15, 20
+	s := maths.NewSerie(3, 0.0)
+	s.Set(1, 10.0)
+	s.Set(2, 20.0)
+	result, err := maths.Resample(s, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := maths.NewSerie(5, 0.0)
+	for index, value := range []float64{0, 5, 10, 15, 20} {
+		expected.Set(index, value)
+	}
+
+	if !result.Equals(expected) {
+		t.Errorf("expected %v, got %v", expected.Values(), result.Values())
+	}
+
+	// downsampling back
+	if back, err := maths.Resample(result, 3); err != nil || !back.Equals(s) {
+		t.Errorf("expected %v, got %v", s.Values(), back.Values())
+	}
+
+	// same size: deep copy
+	copied, _ := maths.Resample(s, 3)
+	copied.Set(0, 100.0)
+	if value, _ := s.Get(0); value != 0.0 {
+		t.Error("resample should not alias its source")
+	}
+
+	// degenerate cases
+	if result, err := maths.Resample(s, 0); err != nil || result.Size() != 0 {
+		t.Error("resample to 0 should return an empty serie")
+	} else if result, err := maths.Resample(s, 1); err != nil || result.Size() != 1 {
+		t.Error("resample to 1 should return the first value")
+	} else if result, err := maths.Resample(maths.NewSerie(1, 7.0), 4); err != nil || !result.Equals(maths.NewSerie(4, 7.0)) {
+		t.Error("resample of a single value should be constant")
+	} else if _, err := maths.Resample(s, -1); err == nil {
+		t.Error("expected error for negative size")
+	} else if _, err := maths.Resample(maths.NewEmptySerie(0.0), 2); err == nil {
+		t.Error("expected error for empty source")
+	}
+}
+
+func TestAlign(t *testing.T) {
+	a := maths.NewSerie(2, 1.0)
+	b := maths.NewSerie(4, 0.0)
+	b.Set(3, 9.0)
+
+	alignedA, alignedB, err := maths.Align(a, b)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if alignedA.Size() != 4 || alignedB.Size() != 4 {
+		t.Fatalf("expected size 4, got %d and %d", alignedA.Size(), alignedB.Size())
+	} else if !alignedA.Equals(maths.NewSerie(4, 1.0)) {
+		t.Errorf("shortest serie should be padded with its default value, got %v", alignedA.Values())
+	} else if !alignedB.Equals(b) {
+		t.Errorf("longest serie should be copied, got %v", alignedB.Values())
+	}
+
+	// copies are deep
+	alignedA.Set(0, 5.0)
+	alignedB.Set(3, 5.0)
+	if value, _ := a.Get(0); value != 1.0 {
+		t.Error("align should not alias its source")
+	} else if value, _ := b.Get(3); value != 9.0 {
+		t.Error("align should not alias its source")
+	}
+}
+
+func TestSerie_EqualsWithin(t *testing.T) {
+	a := maths.NewSerie(3, 1.0)
+	b := maths.NewSerie(3, 1.0)
+	b.Set(1, 1.05)
+
+	if !a.EqualsWithin(b, 0.1) {
+		t.Error("series should be equal within 0.1")
+	} else if a.EqualsWithin(b, 0.01) {
+		t.Error("series should differ outside 0.01")
+	} else if a.Equals(b) {
+		t.Error("series should differ with default precision")
+	} else if a.EqualsWithin(maths.NewSerie(4, 1.0), 0.1) {
+		t.Error("series of different sizes should differ")
+	}
+}
+
+func TestSerie_InterpolateAt(t *testing.T) {
+	s := maths.NewSerie(3, 0.0)
+	s.Set(1, 2.0)
+	s.Set(2, 4.0)
+
+	if value, found := s.InterpolateAt(1.5); !found {
+		t.Error("1.5 should be in range")
+	} else if value != 3.0 {
+		t.Errorf("expected 3, got %f", value)
+	}
+
+	if value, found := s.InterpolateAt(2); !found || value != 4.0 {
+		t.Errorf("last index should return its value, got %f", value)
+	} else if _, found := s.InterpolateAt(-0.5); found {
+		t.Error("negative position should be out of range")
+	} else if _, found := s.InterpolateAt(2.5); found {
+		t.Error("position after last index should be out of range")
+	} else if _, found := maths.NewEmptySerie(0.0).InterpolateAt(0); found {
+		t.Error("empty serie has no value to interpolate")
+	}
+}
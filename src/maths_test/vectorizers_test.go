@@ -0,0 +1,80 @@
+package maths_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zefrenchwan/perspectives.git/maths"
+)
+
+func TestAttributesVectorizer(t *testing.T) {
+	vectorizer := maths.NewAttributesVectorizer([]string{"weight", "age", "height"}, false)
+
+	// same attributes, different insertion order
+	first := map[string]float64{"age": 30, "height": 1.8, "weight": 80}
+	second := make(map[string]float64)
+	second["weight"] = 80
+	second["height"] = 1.8
+	second["age"] = 30
+
+	a, errA := vectorizer(first)
+	b, errB := vectorizer(second)
+	if errA != nil || errB != nil {
+		t.Fatal("unexpected error")
+	} else if !a.Equals(b) {
+		t.Error("same attributes should vectorize the same")
+	} else if !a.Equals(maths.NewColumnMatrix([]float64{30, 1.8, 80})) {
+		t.Errorf("attributes should be sorted by name, got %v", a.Export())
+	}
+
+	// missing attributes
+	if result, err := vectorizer(map[string]float64{"age": 30}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if !result.Equals(maths.NewColumnMatrix([]float64{30, 0, 0})) {
+		t.Errorf("missing attributes should be 0, got %v", result.Export())
+	}
+
+	strict := maths.NewAttributesVectorizer([]string{"age", "height"}, true)
+	if _, err := strict(map[string]float64{"age": 30}); err == nil {
+		t.Error("expected error for missing attribute")
+	} else if _, err := strict("age"); err == nil {
+		t.Error("expected error for unsupported value")
+	}
+}
+
+func TestComposeVectorizers(t *testing.T) {
+	first := maths.NewAttributesVectorizer([]string{"a", "b"}, false)
+	second := maths.NewAttributesVectorizer([]string{"c", "d", "e"}, false)
+	composed := maths.ComposeVectorizers(first, second)
+
+	result, err := composed(map[string]float64{"a": 1, "e": 5})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if result.Size() != 5 {
+		t.Errorf("expected size 2 + 3, got %d", result.Size())
+	} else if !result.Equals(maths.NewColumnMatrix([]float64{1, 0, 0, 0, 5})) {
+		t.Errorf("unexpected vector %v", result.Export())
+	}
+
+	failing := func(any) (maths.ColumnMatrix, error) { return nil, errors.New("failure") }
+	if _, err := maths.ComposeVectorizers(first, failing)(map[string]float64{}); err == nil {
+		t.Error("expected error when inner vectorizer fails")
+	}
+}
+
+func TestVectorizerRegistry(t *testing.T) {
+	registry := maths.NewVectorizerRegistry()
+	if err := registry.Register("ab", maths.NewAttributesVectorizer([]string{"a", "b"}, false)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if err := registry.Register("nil", nil); err == nil {
+		t.Error("expected error for nil vectorizer")
+	}
+
+	if result, err := registry.Vectorize("ab", map[string]float64{"b": 2}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	} else if !result.Equals(maths.NewColumnMatrix([]float64{0, 2})) {
+		t.Errorf("unexpected vector %v", result.Export())
+	} else if _, err := registry.Vectorize("unknown", nil); err == nil {
+		t.Error("expected error for unknown vectorizer")
+	}
+}
@@ -69,7 +69,7 @@ func TestNormColumnMatrix(t *testing.T) {
 	a := maths.NewColumnMatrix([]float64{1, 2, 3})
 	nulVector := maths.NewColumnMatrix([]float64{0, 0, 0})
 
-	if math.Abs(a.Norm()-14.0) >= 0.0001 {
+	if math.Abs(a.Norm()-math.Sqrt(14.0)) >= 0.0001 {
 		t.Log("failed norm")
 		t.Fail()
 	}
@@ -332,3 +332,239 @@ func TestColumnMatrixExport(t *testing.T) {
 		}
 	}
 }
+
+func TestColumnMatrixExportIsACopy(t *testing.T) {
+	c := maths.NewColumnMatrix([]float64{1, 2, 3})
+	c.Export()[0] = 10
+
+	if !c.Equals(maths.NewColumnMatrix([]float64{1, 2, 3})) {
+		t.Error("changing exported values should not change the matrix")
+	} else if c.Norm() != math.Sqrt(14.0) {
+		t.Error("norm should not see exported changes")
+	}
+}
+
+func TestColumnMatrixSub(t *testing.T) {
+	a := maths.NewColumnMatrix([]float64{1, 2, 3})
+	b := maths.NewColumnMatrix([]float64{3, 2, 1})
+
+	if _, err := a.Sub(maths.NewColumnMatrix([]float64{1})); err == nil {
+		t.Error("size mismatch not seen")
+	}
+
+	expected := maths.NewColumnMatrix([]float64{-2, 0, 2})
+	if result, err := a.Sub(b); err != nil {
+		t.Error(err)
+	} else if !result.Equals(expected) {
+		t.Errorf("expected %v, got %v", expected.Export(), result.Export())
+	}
+
+	empty := maths.NewColumnMatrix(nil)
+	if result, err := empty.Sub(empty); err != nil {
+		t.Error(err)
+	} else if result.Size() != 0 {
+		t.Error("difference of empty vectors should be empty")
+	}
+}
+
+func TestColumnMatrixScaleAndDot(t *testing.T) {
+	a := maths.NewColumnMatrix([]float64{1, 2})
+	b := maths.NewColumnMatrix([]float64{3, 4})
+
+	if !a.Scale(0.5).Equals(maths.NewColumnMatrix([]float64{0.5, 1})) {
+		t.Error("failed scale")
+	}
+
+	if result, err := a.Dot(b); err != nil {
+		t.Error(err)
+	} else if result != 11.0 {
+		t.Errorf("expected 11, got %f", result)
+	} else if _, err := a.Dot(maths.NewColumnMatrix(nil)); err == nil {
+		t.Error("size mismatch not seen")
+	}
+
+	empty := maths.NewColumnMatrix(nil)
+	if result, err := empty.Dot(empty); err != nil || result != 0.0 {
+		t.Error("dot product of empty vectors should be 0")
+	} else if empty.Scale(2.0).Size() != 0 {
+		t.Error("scaled empty vector should be empty")
+	}
+}
+
+func TestColumnMatrixNormalize(t *testing.T) {
+	a := maths.NewColumnMatrix([]float64{3, 4})
+	expected := maths.NewColumnMatrix([]float64{0.6, 0.8})
+
+	if a.Norm() != 5.0 {
+		t.Errorf("expected norm 5, got %f", a.Norm())
+	}
+
+	if result, err := a.Normalize(); err != nil {
+		t.Error(err)
+	} else if !result.Equals(expected) {
+		t.Errorf("expected %v, got %v", expected.Export(), result.Export())
+	} else if math.Abs(result.Norm()-1.0) > 0.0001 {
+		t.Error("normalized vector should have norm 1")
+	}
+
+	if _, err := maths.NewColumnMatrix([]float64{0, 0}).Normalize(); err == nil {
+		t.Error("zero vector should not be normalized")
+	} else if _, err := maths.NewColumnMatrix(nil).Normalize(); err == nil {
+		t.Error("empty vector should not be normalized")
+	}
+}
+
+// naiveProduct is the reference triple loop, reading the second matrix column by column
+func naiveProduct(a, b [][]float64) [][]float64 {
+	size := len(a)
+	result := make([][]float64, size)
+	for i := 0; i < size; i++ {
+		result[i] = make([]float64, size)
+		for j := 0; j < size; j++ {
+			for k := 0; k < size; k++ {
+				result[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+
+	return result
+}
+
+// buildTestMatrix returns a deterministic matrix with few zeros
+func buildTestMatrix(size int, seed float64) maths.SquareMatrix {
+	elements := make([][]float64, size)
+	for i := 0; i < size; i++ {
+		elements[i] = make([]float64, size)
+		for j := 0; j < size; j++ {
+			elements[i][j] = math.Mod(seed*float64(i+1)+float64(j*j), 7.0) - 3.0
+		}
+	}
+
+	result, _ := maths.NewSquareMatrix(size, elements)
+	return result
+}
+
+func TestSquareMatrixMultiplyMatrix(t *testing.T) {
+	a, _ := maths.NewSquareMatrix(2, [][]float64{{1, 2}, {3, 4}})
+	b, _ := maths.NewSquareMatrix(2, [][]float64{{5, 6}, {7, 8}})
+	expected, _ := maths.NewSquareMatrix(2, [][]float64{{19, 22}, {43, 50}})
+
+	if result, err := a.MultiplyMatrix(b); err != nil {
+		t.Fatal(err)
+	} else if !result.Equals(expected) {
+		t.Errorf("expected %v, got %v", expected, result)
+	}
+
+	if _, err := a.MultiplyMatrix(maths.NewIdentityMatrix(3)); err == nil {
+		t.Error("size mismatch not seen")
+	}
+
+	if result, err := maths.NewIdentityMatrix(0).MultiplyMatrix(maths.NewIdentityMatrix(0)); err != nil {
+		t.Error(err)
+	} else if result.Size() != 0 {
+		t.Error("empty product should be empty")
+	}
+
+	// compare with the reference implementation
+	left, right := buildTestMatrix(13, 1.5), buildTestMatrix(13, 2.5)
+	reference, _ := maths.NewSquareMatrix(13, naiveProduct(left.Export(), right.Export()))
+	if result, err := left.MultiplyMatrix(right); err != nil {
+		t.Fatal(err)
+	} else if !result.Equals(reference) {
+		t.Error("product differs from naive product")
+	}
+}
+
+func TestSquareMatrixMultiplyMatrixSpecialValues(t *testing.T) {
+	// 0 * Inf and 0 * NaN are NaN, as in the naive product
+	zeros, _ := maths.NewSquareMatrix(2, [][]float64{{0, 0}, {1, 0}})
+	special, _ := maths.NewSquareMatrix(2, [][]float64{{math.Inf(1), 1}, {math.NaN(), 2}})
+
+	result, err := zeros.MultiplyMatrix(special)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reference := naiveProduct(zeros.Export(), special.Export())
+	for i, row := range result.Export() {
+		for j, value := range row {
+			expected := reference[i][j]
+			if math.IsNaN(expected) != math.IsNaN(value) || (!math.IsNaN(expected) && expected != value) {
+				t.Errorf("at (%d, %d), expected %f, got %f", i, j, expected, value)
+			}
+		}
+	}
+
+	if value := result.Export()[0][0]; !math.IsNaN(value) {
+		t.Errorf("0 * Inf + 0 * NaN should be NaN, got %f", value)
+	}
+}
+
+func TestSquareMatrixIdentity(t *testing.T) {
+	a, _ := maths.NewSquareMatrix(3, [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+	identity := maths.NewIdentityMatrix(3)
+	fromDiagonal := maths.NewDiagonalMatrix([]float64{1, 1, 1})
+	explicit, _ := maths.NewSquareMatrix(3, [][]float64{{1, 0, 0}, {0, 1, 0}, {0, 0, 1}})
+
+	if !identity.Equals(fromDiagonal) || !identity.Equals(explicit) || !explicit.Equals(fromDiagonal) {
+		t.Error("matrices built by different constructors should be equal")
+	}
+
+	if result, _ := a.MultiplyMatrix(identity); !result.Equals(a) {
+		t.Error("a * I should be a")
+	} else if result, _ := identity.MultiplyMatrix(a); !result.Equals(a) {
+		t.Error("I * a should be a")
+	}
+
+	diagonal := maths.NewDiagonalMatrix([]float64{2, 0, -1})
+	expected, _ := maths.NewSquareMatrix(3, [][]float64{{2, 4, 6}, {0, 0, 0}, {-7, -8, -9}})
+	if result, _ := diagonal.MultiplyMatrix(a); !result.Equals(expected) {
+		t.Errorf("diagonal product should scale rows, got %v", result)
+	}
+
+	if maths.NewIdentityMatrix(-1).Size() != 0 {
+		t.Error("negative size should build an empty matrix")
+	}
+}
+
+func TestSquareMatrixTranspose(t *testing.T) {
+	a, _ := maths.NewSquareMatrix(2, [][]float64{{1, 2}, {3, 4}})
+	expected, _ := maths.NewSquareMatrix(2, [][]float64{{1, 3}, {2, 4}})
+
+	transposed := a.Transpose()
+	if !transposed.Equals(expected) {
+		t.Errorf("expected %v, got %v", expected, transposed)
+	} else if !transposed.Transpose().Equals(a) {
+		t.Error("transpose should be an involution")
+	}
+
+	// result is a copy
+	transposed.Export()[0][1] = 10
+	if value, _ := a.Row(1); value[0] != 3 {
+		t.Error("transpose should not alias its source")
+	}
+
+	// (AB)^T = B^T A^T
+	left, right := buildTestMatrix(5, 1.0), buildTestMatrix(5, 3.0)
+	product, _ := left.MultiplyMatrix(right)
+	reversed, _ := right.Transpose().MultiplyMatrix(left.Transpose())
+	if !product.Transpose().Equals(reversed) {
+		t.Error("(AB)^T should be B^T A^T")
+	}
+}
+
+func BenchmarkSquareMatrixNaiveProduct(b *testing.B) {
+	left, right := buildTestMatrix(128, 1.5).Export(), buildTestMatrix(128, 2.5).Export()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		naiveProduct(left, right)
+	}
+}
+
+func BenchmarkSquareMatrixMultiplyMatrix(b *testing.B) {
+	left, right := buildTestMatrix(128, 1.5), buildTestMatrix(128, 2.5)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		left.MultiplyMatrix(right)
+	}
+}
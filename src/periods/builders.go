@@ -0,0 +1,48 @@
+package periods
+
+import "time"
+
+// PeriodBuilder accumulates intervals and merges them once, when building the period.
+// It avoids the cost of repeated unions when a period is made of many small intervals.
+type PeriodBuilder struct {
+	// intervals accumulated so far, not merged
+	intervals []interval
+	// precision to truncate boundaries of added intervals
+	precision time.Duration
+}
+
+// NewPeriodBuilder returns an empty builder, using the package precision at creation time
+func NewPeriodBuilder() *PeriodBuilder {
+	return NewPeriodBuilderWithPrecision(TimePrecision())
+}
+
+// NewPeriodBuilderWithPrecision returns an empty builder truncating boundaries to precision (0 keeps them as is)
+func NewPeriodBuilderWithPrecision(precision time.Duration) *PeriodBuilder {
+	return &PeriodBuilder{precision: precision}
+}
+
+// Add appends the finite interval (start, end), boundaries truncated to the builder precision.
+// Mathematically empty intervals are ignored
+func (b *PeriodBuilder) Add(start, end time.Time, startIn, endIn bool) {
+	if value := newIntervalDuring(start, end, startIn, endIn, b.precision); !value.empty {
+		b.intervals = append(b.intervals, value)
+	}
+}
+
+// AddPeriod appends all the intervals of a period
+func (b *PeriodBuilder) AddPeriod(period Period) {
+	b.intervals = append(b.intervals, period.intervals...)
+}
+
+// Build returns the union of all added intervals.
+// Builder may still be used after, to add more intervals
+func (b *PeriodBuilder) Build() Period {
+	if len(b.intervals) == 0 {
+		return Period{}
+	}
+
+	// copy so that the built period does not share memory with the builder
+	values := make([]interval, len(b.intervals))
+	copy(values, b.intervals)
+	return Period{intervals: intervalsUnionAll(values)}
+}
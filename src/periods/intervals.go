@@ -4,6 +4,7 @@ import (
 	"errors"
 	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/zefrenchwan/perspectives.git/configuration"
@@ -16,10 +17,32 @@ const INTERVAL_EMPTY = "]["
 const INTERVAL_VALUE_LEFT_INFINITY = "-oo"
 const INTERVAL_VALUE_RIGHT_INFINITY = "+oo"
 
+// precisionSetting stores the precision used to truncate boundaries of new intervals, in nanoseconds.
+// 0 means default, that is configuration.TIME_PRECISION
+var precisionSetting atomic.Int64
+
+// SetTimePrecision changes the precision used to truncate boundaries of periods built afterwards.
+// A non positive value restores the default precision, configuration.TIME_PRECISION.
+// Existing periods are not changed. It is safe to call it concurrently,
+// but periods built at the same time may use either precision: set it at start-up.
+func SetTimePrecision(precision time.Duration) {
+	precisionSetting.Store(int64(max(precision, 0)))
+}
+
+// TimePrecision returns the precision currently used to truncate boundaries of new periods
+func TimePrecision() time.Duration {
+	if value := precisionSetting.Load(); value > 0 {
+		return time.Duration(value)
+	}
+
+	return configuration.TIME_PRECISION
+}
+
 // interval is an interval of time.
 // It uses:
 // empty that overrides the rest
 // left and right boundaries represented by: finite or not, included or not (if finite), bounds as time.Time (if finite )
+// Each finite boundary keeps the precision it was truncated to, see bound.
 type interval struct {
 	// empty is true for empty sets, overrides every other info
 	empty bool
@@ -35,44 +58,152 @@ type interval struct {
 	leftMoment time.Time
 	// right finite border
 	rightMoment time.Time
+	// leftPrecision is the precision left border was truncated to, 0 for no truncation
+	leftPrecision time.Duration
+	// rightPrecision is the precision right border was truncated to, 0 for no truncation
+	rightPrecision time.Duration
+}
+
+// bound is the actual limit of a finite boundary, once its precision is applied.
+// Moments are truncated to the precision of the boundary before comparison.
+// So, with a positive precision d and a truncated moment m:
+// an excluded left m accepts moments from m+d on, an included right m accepts moments before m+d.
+// Then, with a positive precision, left bounds are closed and right bounds are open.
+// Comparing bounds and not raw boundaries keeps set operations consistent with contains,
+// whatever the precisions of the intervals.
+type bound struct {
+	// moment is the limit
+	moment time.Time
+	// closed is true if moment itself is accepted
+	closed bool
+}
+
+// leftBound returns the actual limit of a left boundary
+func leftBound(moment time.Time, included bool, precision time.Duration) bound {
+	switch {
+	case precision <= 0:
+		return bound{moment: moment, closed: included}
+	case included:
+		return bound{moment: moment, closed: true}
+	default:
+		return bound{moment: moment.Add(precision), closed: true}
+	}
+}
+
+// rightBound returns the actual limit of a right boundary
+func rightBound(moment time.Time, included bool, precision time.Duration) bound {
+	switch {
+	case precision <= 0:
+		return bound{moment: moment, closed: included}
+	case included:
+		return bound{moment: moment.Add(precision), closed: false}
+	default:
+		return bound{moment: moment, closed: false}
+	}
 }
 
-// buildInterval returns an interval built from values (may be empty if values lead to it)
-func buildInterval(empty, minFinite, maxFinite bool, min, max time.Time, minIn, maxIn bool) interval {
+// left returns the actual limit of the left boundary, if finite
+func (i interval) left() bound {
+	return leftBound(i.leftMoment, i.leftIncluded, i.leftPrecision)
+}
+
+// right returns the actual limit of the right boundary, if finite
+func (i interval) right() bound {
+	return rightBound(i.rightMoment, i.rightIncluded, i.rightPrecision)
+}
+
+// compareLeftBounds returns a negative value if a accepts moments before b does, 0 if same bound
+func compareLeftBounds(a, b bound) int {
+	if comparison := a.moment.Compare(b.moment); comparison != 0 {
+		return comparison
+	} else if a.closed == b.closed {
+		return 0
+	} else if a.closed {
+		return -1
+	} else {
+		return 1
+	}
+}
+
+// compareRightBounds returns a negative value if a stops accepting moments before b does, 0 if same bound
+func compareRightBounds(a, b bound) int {
+	if comparison := a.moment.Compare(b.moment); comparison != 0 {
+		return comparison
+	} else if a.closed == b.closed {
+		return 0
+	} else if a.closed {
+		return 1
+	} else {
+		return -1
+	}
+}
+
+// isBetween returns true if at least one moment is after left and before right
+func isBetween(left, right bound) bool {
+	comparison := left.moment.Compare(right.moment)
+	return comparison < 0 || (comparison == 0 && left.closed && right.closed)
+}
+
+// isConnected returns true if ]-oo, right) union (left, +oo[ has no hole
+func isConnected(right, left bound) bool {
+	comparison := right.moment.Compare(left.moment)
+	return comparison > 0 || (comparison == 0 && (right.closed || left.closed))
+}
+
+// storedPrecision returns precision to store in an interval: non positive values mean no truncation, stored as 0
+func storedPrecision(precision time.Duration) time.Duration {
+	if precision <= 0 {
+		return 0
+	}
+
+	return precision
+}
+
+// loadedPrecision returns the package precision if it keeps moment unchanged, 0 (no truncation) otherwise.
+// It is used for boundaries read from outside, that should never be changed
+func loadedPrecision(moment time.Time) time.Duration {
+	precision := TimePrecision()
+	if !moment.Truncate(precision).Equal(moment) {
+		return 0
+	}
+
+	return precision
+}
+
+// buildInterval returns an interval built from values (may be empty if values lead to it).
+// Finite values are truncated to precision, a non positive precision keeps them as is
+func buildInterval(empty, minFinite, maxFinite bool, min, max time.Time, minIn, maxIn bool, precision time.Duration) interval {
+	return buildIntervalWithPrecisions(empty, minFinite, maxFinite, min, max, minIn, maxIn, precision, precision)
+}
+
+// buildIntervalWithPrecisions returns an interval built from values (may be empty if values lead to it).
+// Each finite value is truncated to its precision, a non positive precision keeps it as is
+func buildIntervalWithPrecisions(empty, minFinite, maxFinite bool, min, max time.Time, minIn, maxIn bool, minPrecision, maxPrecision time.Duration) interval {
 	if empty {
 		return interval{empty: true}
+	} else if !minFinite && !maxFinite {
+		// nothing to truncate
+		return newFullInterval()
 	}
 
-	var left, right time.Time
-	var leftIn, rightIn bool
+	result := interval{empty: false, leftFinite: minFinite, rightFinite: maxFinite}
 	if minFinite {
-		left = min.Truncate(configuration.TIME_PRECISION)
-		leftIn = minIn
-	} else {
-		leftIn = false
+		result.leftMoment = min.Truncate(minPrecision)
+		result.leftIncluded = minIn
+		result.leftPrecision = storedPrecision(minPrecision)
 	}
 
 	if maxFinite {
-		right = max.Truncate(configuration.TIME_PRECISION)
-		rightIn = maxIn
-	} else {
-		rightIn = false
+		result.rightMoment = max.Truncate(maxPrecision)
+		result.rightIncluded = maxIn
+		result.rightPrecision = storedPrecision(maxPrecision)
 	}
 
-	if minFinite && maxFinite {
-		comparison := left.Compare(right)
-		switch {
-		case comparison > 0:
-			return interval{empty: true}
-		case comparison == 0 && !(maxIn && minIn):
-			return interval{empty: true}
-		}
+	if minFinite && maxFinite && !isBetween(result.left(), result.right()) {
+		return interval{empty: true}
 	}
 
-	return interval{empty: false,
-		leftFinite: minFinite, leftMoment: left, leftIncluded: leftIn,
-		rightFinite: maxFinite, rightMoment: right, rightIncluded: rightIn,
-	}
+	return result
 }
 
 // newFullInterval builds a new interval equals to full space
@@ -80,54 +211,26 @@ func newFullInterval() interval {
 	return interval{empty: false, leftFinite: false, rightFinite: false}
 }
 
-// newIntervalSince builds the interval (leftLimit, +oo[
-func newIntervalSince(leftLimit time.Time, leftIn bool) interval {
-	return interval{
-		empty:        false,
-		rightFinite:  false,
-		leftFinite:   true,
-		leftIncluded: leftIn,
-		leftMoment:   leftLimit.Truncate(configuration.TIME_PRECISION),
-	}
-
+// newIntervalSince builds the interval (leftLimit, +oo[ with leftLimit truncated to precision
+func newIntervalSince(leftLimit time.Time, leftIn bool, precision time.Duration) interval {
+	return buildInterval(false, true, false, leftLimit, time.Time{}, leftIn, false, precision)
 }
 
-// newIntervalUntil builds the interval ]-oo, rightLimit)
-func newIntervalUntil(rightLimit time.Time, rightIn bool) interval {
-	return interval{
-		empty:         false,
-		leftFinite:    false,
-		rightFinite:   true,
-		rightIncluded: rightIn,
-		rightMoment:   rightLimit.Truncate(configuration.TIME_PRECISION),
-	}
+// newIntervalUntil builds the interval ]-oo, rightLimit) with rightLimit truncated to precision
+func newIntervalUntil(rightLimit time.Time, rightIn bool, precision time.Duration) interval {
+	return buildInterval(false, false, true, time.Time{}, rightLimit, false, rightIn, precision)
 }
 
 // newIntervalDuring returns the interval (min,max) or empty when result is mathematically empty.
-// If min > max, for instance, result is mathematically empty and so is result of the function
-func newIntervalDuring(min, max time.Time, minIncluded, maxIncluded bool) interval {
-	left := min.Truncate(configuration.TIME_PRECISION)
-	right := max.Truncate(configuration.TIME_PRECISION)
-	comparison := left.Compare(right)
-	switch {
-	case comparison > 0:
-		return interval{empty: true}
-	case comparison == 0 && !(minIncluded && maxIncluded):
-		return interval{empty: true}
-	default:
-		return interval{
-			empty:         false,
-			leftFinite:    true,
-			rightFinite:   true,
-			leftIncluded:  minIncluded,
-			rightIncluded: maxIncluded,
-			leftMoment:    min.Truncate(configuration.TIME_PRECISION),
-			rightMoment:   max.Truncate(configuration.TIME_PRECISION),
-		}
-	}
+// If min > max, for instance, result is mathematically empty and so is result of the function.
+// Boundaries are truncated to precision
+func newIntervalDuring(min, max time.Time, minIncluded, maxIncluded bool, precision time.Duration) interval {
+	return buildInterval(false, true, true, min, max, minIncluded, maxIncluded, precision)
 }
 
-// intervalEquals tests if two periods are the same
+// intervalEquals tests if two periods are the same set of moments.
+// Boundaries are compared by their actual bound, so precision is taken into account:
+// with a one second precision, ]t, +oo[ is [t+1s, +oo[
 func intervalEquals(a, b interval) bool {
 	if a.empty != b.empty {
 		return false
@@ -139,20 +242,10 @@ func intervalEquals(a, b interval) bool {
 		return false
 	}
 
-	if a.leftFinite {
-		if a.leftIncluded != b.leftIncluded {
-			return false
-		} else if a.leftMoment.Compare(b.leftMoment) != 0 {
-			return false
-		}
-	}
-
-	if a.rightFinite {
-		if a.rightIncluded != b.rightIncluded {
-			return false
-		} else if !a.rightMoment.Equal(b.rightMoment) {
-			return false
-		}
+	if a.leftFinite && compareLeftBounds(a.left(), b.left()) != 0 {
+		return false
+	} else if a.rightFinite && compareRightBounds(a.right(), b.right()) != 0 {
+		return false
 	}
 
 	return true
@@ -173,15 +266,8 @@ func intervalCompare(a, b interval) int {
 	} else if !a.leftFinite && b.leftFinite {
 		return -1
 	} else if a.leftFinite && b.leftFinite {
-		comparison := a.leftMoment.Compare(b.leftMoment)
-		if comparison != 0 {
+		if comparison := compareLeftBounds(a.left(), b.left()); comparison != 0 {
 			return comparison
-		} else if a.leftIncluded != b.leftIncluded {
-			if a.leftIncluded {
-				return 1
-			} else {
-				return -1
-			}
 		}
 	}
 
@@ -191,16 +277,7 @@ func intervalCompare(a, b interval) int {
 	} else if !a.rightFinite && b.rightFinite {
 		return 1
 	} else if a.rightFinite && b.rightFinite {
-		comparison := a.rightMoment.Compare(b.rightMoment)
-		if comparison != 0 {
-			return comparison
-		} else if a.rightIncluded == b.rightIncluded {
-			return 0
-		} else if a.rightIncluded {
-			return 1
-		} else {
-			return -1
-		}
+		return compareRightBounds(a.right(), b.right())
 	}
 
 	return 0
@@ -211,7 +288,8 @@ func (i interval) isFull() bool {
 	return !i.empty && !i.leftFinite && !i.rightFinite
 }
 
-// contains returns true if point is in the interval (as in set theory)
+// contains returns true if point is in the interval (as in set theory).
+// Point is compared to the actual bounds, that is as if it was truncated to the precision of each boundary
 func (i interval) contains(point time.Time) bool {
 	if i.empty {
 		return false
@@ -221,27 +299,29 @@ func (i interval) contains(point time.Time) bool {
 
 	// test if point is less than L and, in that case, return false for sure
 	if i.leftFinite {
-		comparison := i.leftMoment.Compare(point)
+		left := i.left()
+		comparison := left.moment.Compare(point)
 		switch {
 		case comparison > 0:
 			// L > point, ie. point < L, so false for sure
 			return false
 		case comparison == 0:
 			// L == point, so depends if L is in or not
-			return i.leftIncluded
+			return left.closed
 		}
 	}
 
 	// test is point is more than R and, in that case, return false for sure
 	if i.rightFinite {
-		comparison := i.rightMoment.Compare(point)
+		right := i.right()
+		comparison := right.moment.Compare(point)
 		switch {
 		case comparison < 0:
 			// R < point, so return false for sure
 			return false
 		case comparison == 0:
 			// R == point, so depends if R is in or not
-			return i.rightIncluded
+			return right.closed
 		}
 	}
 
@@ -256,62 +336,52 @@ func (i interval) isIncludedIn(other interval) bool {
 		return false
 	}
 
-	var isIncludedLeft bool
 	// i is in other if
 	// (i.left bound) is more than (other.left bound)
 	// AND
 	// (i.right bound) is less than (other.right bound)
-	if other.leftFinite && !i.leftFinite {
-		// no need to go deeper
+	if other.leftFinite && (!i.leftFinite || compareLeftBounds(i.left(), other.left()) < 0) {
+		return false
+	} else if other.rightFinite && (!i.rightFinite || compareRightBounds(i.right(), other.right()) > 0) {
 		return false
-	} else if !i.leftFinite && !other.leftFinite {
-		isIncludedLeft = true
-	} else if !other.leftFinite && i.leftFinite {
-		isIncludedLeft = true
-	} else {
-		comparison := i.leftMoment.Compare(other.leftMoment)
-		switch {
-		case comparison < 0:
-			return false
-		case comparison > 0:
-			isIncludedLeft = true
-		case comparison == 0:
-			if i.leftIncluded {
-				isIncludedLeft = other.leftIncluded
-			} else {
-				isIncludedLeft = true
-			}
-		}
 	}
 
-	// no need to go deeper
-	if !isIncludedLeft {
-		return false
+	return true
+}
+
+// shift returns the interval translated by delta.
+// Inclusion flags and infinite sides are preserved, finite boundaries are moved and truncated to their precision.
+func (i interval) shift(delta time.Duration) interval {
+	if i.empty || i.isFull() {
+		return i
 	}
 
-	// same on the right side
-	if other.rightFinite && !i.rightFinite {
-		return false
-	} else if !other.rightFinite {
-		return isIncludedLeft
-	} else {
-		comparison := i.rightMoment.Compare(other.rightMoment)
-		switch {
-		case comparison < 0:
-			return isIncludedLeft
-		case comparison > 0:
-			return false
-		case other.rightIncluded:
-			return isIncludedLeft
-		case !other.rightIncluded:
-			return isIncludedLeft && !i.rightIncluded
-		default:
-			return false
-		}
+	return buildIntervalWithPrecisions(false,
+		i.leftFinite, i.rightFinite,
+		i.leftMoment.Add(delta), i.rightMoment.Add(delta),
+		i.leftIncluded, i.rightIncluded,
+		i.leftPrecision, i.rightPrecision,
+	)
+}
+
+// extend moves left boundary earlier by left and right boundary later by right.
+// Infinite sides are unchanged, negative durations shrink the interval (that may become empty).
+// Moved boundaries are truncated to their precision.
+func (i interval) extend(left, right time.Duration) interval {
+	if i.empty || i.isFull() {
+		return i
 	}
+
+	return buildIntervalWithPrecisions(false,
+		i.leftFinite, i.rightFinite,
+		i.leftMoment.Add(-left), i.rightMoment.Add(right),
+		i.leftIncluded, i.rightIncluded,
+		i.leftPrecision, i.rightPrecision,
+	)
 }
 
-// intervalsIntersection returns the intersection of all parameters
+// intervalsIntersection returns the intersection of all parameters.
+// Each boundary of the result is a boundary of a parameter, with its precision
 func intervalsIntersection(intervals []interval) interval {
 	var remaining []interval
 	var empty bool
@@ -336,47 +406,26 @@ func intervalsIntersection(intervals []interval) interval {
 
 		// calculate the actual intersection between intersection and value
 		// Get the max value for left bounds
-		if value.leftFinite {
-			if !intersection.leftFinite {
-				intersection.leftFinite = value.leftFinite
-				intersection.leftIncluded = value.leftIncluded
-				intersection.leftMoment = value.leftMoment
-			} else {
-				comparison := intersection.leftMoment.Compare(value.leftMoment)
-				if comparison < 0 || (comparison == 0 && value.leftIncluded) {
-					intersection.leftFinite = value.leftFinite
-					intersection.leftIncluded = value.leftIncluded
-					intersection.leftMoment = value.leftMoment
-				}
-			}
+		if value.leftFinite && (!intersection.leftFinite || compareLeftBounds(intersection.left(), value.left()) < 0) {
+			intersection.leftFinite = true
+			intersection.leftIncluded = value.leftIncluded
+			intersection.leftMoment = value.leftMoment
+			intersection.leftPrecision = value.leftPrecision
 		}
+
 		// Get the min value for right bounds
-		if value.rightFinite {
-			if !intersection.rightFinite {
-				intersection.rightFinite = value.rightFinite
-				intersection.rightIncluded = value.rightIncluded
-				intersection.rightMoment = value.rightMoment
-			} else {
-				comparison := intersection.rightMoment.Compare(value.rightMoment)
-				if comparison > 0 || (comparison == 0 && !value.rightIncluded) {
-					intersection.rightFinite = value.rightFinite
-					intersection.rightIncluded = value.rightIncluded
-					intersection.rightMoment = value.rightMoment
-				}
-			}
+		if value.rightFinite && (!intersection.rightFinite || compareRightBounds(intersection.right(), value.right()) > 0) {
+			intersection.rightFinite = true
+			intersection.rightIncluded = value.rightIncluded
+			intersection.rightMoment = value.rightMoment
+			intersection.rightPrecision = value.rightPrecision
 		}
 	}
 
 	// then, test if intersection is empty or not.
 	// Interval is built but may be empty
-	if intersection.leftFinite && intersection.rightFinite {
-		comparison := intersection.leftMoment.Compare(intersection.rightMoment)
-		switch {
-		case comparison == 0 && !(intersection.leftIncluded && intersection.rightIncluded):
-			return interval{empty: true}
-		case comparison > 0:
-			return interval{empty: true}
-		}
+	if intersection.leftFinite && intersection.rightFinite && !isBetween(intersection.left(), intersection.right()) {
+		return interval{empty: true}
 	}
 
 	return intersection
@@ -427,7 +476,7 @@ func intervalFromString(rawData string) (interval, error) {
 
 	leftInfinite, rightInfinite := leftPart == INTERVAL_VALUE_LEFT_INFINITY, rightPart == INTERVAL_VALUE_RIGHT_INFINITY
 	if leftInfinite && rightInfinite {
-		return interval{empty: false, leftFinite: false, rightFinite: false}, nil
+		return newFullInterval(), nil
 	}
 
 	leftIn, rightIn := leftBound == INTERVAL_BOUNDARY_RIGHT, rightBound == INTERVAL_BOUNDARY_LEFT
@@ -452,22 +501,28 @@ func intervalFromString(rawData string) (interval, error) {
 		}
 	}
 
-	// and (finally) make the interval
-	if leftInfinite {
-		return interval{empty: false, leftFinite: false, rightFinite: true, rightIncluded: rightIn, rightMoment: rightVal}, nil
-	} else if rightInfinite {
-		return interval{empty: false, leftFinite: true, rightFinite: false, leftIncluded: leftIn, leftMoment: leftVal}, nil
+	// check raw values, as in the serialized form
+	if !leftInfinite && !rightInfinite {
+		comparison := leftVal.Compare(rightVal)
+		if comparison > 0 {
+			return empty, errors.New("min value is more than max value")
+		} else if comparison == 0 && (!leftIn || !rightIn) {
+			return empty, errors.New("min value equals max value but boundaries are not included")
+		}
+	}
+
+	// and (finally) make the interval.
+	// Loaded boundaries are not changed, so precision of each depends on its value
+	var leftPrecision, rightPrecision time.Duration
+	if !leftInfinite {
+		leftPrecision = loadedPrecision(leftVal)
 	}
 
-	comparison := leftVal.Compare(rightVal)
-	if comparison > 0 {
-		return empty, errors.New("min value is more than max value")
-	} else if comparison == 0 && (!leftIn || !rightIn) {
-		return empty, errors.New("min value equals max value but boundaries are not included")
+	if !rightInfinite {
+		rightPrecision = loadedPrecision(rightVal)
 	}
 
-	// finite interval build
-	return interval{empty: false, leftFinite: true, rightFinite: true, leftIncluded: leftIn, rightIncluded: rightIn, leftMoment: leftVal, rightMoment: rightVal}, nil
+	return buildIntervalWithPrecisions(false, !leftInfinite, !rightInfinite, leftVal, rightVal, leftIn, rightIn, leftPrecision, rightPrecision), nil
 }
 
 // toString returns the interval as a string.
@@ -518,28 +573,29 @@ func (i interval) complement() []interval {
 	} else if !i.leftFinite {
 		return []interval{{
 			empty: false, leftFinite: true, rightFinite: false,
-			leftIncluded: !i.rightIncluded, leftMoment: i.rightMoment},
+			leftIncluded: !i.rightIncluded, leftMoment: i.rightMoment, leftPrecision: i.rightPrecision},
 		}
 	} else if !i.rightFinite {
 		return []interval{{
 			empty: false, leftFinite: false, rightFinite: true,
-			rightIncluded: !i.leftIncluded, rightMoment: i.leftMoment},
+			rightIncluded: !i.leftIncluded, rightMoment: i.leftMoment, rightPrecision: i.leftPrecision},
 		}
 	} else {
 		return []interval{
 			{
 				empty: false, leftFinite: false, rightFinite: true,
-				rightIncluded: !i.leftIncluded, rightMoment: i.leftMoment,
+				rightIncluded: !i.leftIncluded, rightMoment: i.leftMoment, rightPrecision: i.leftPrecision,
 			},
 			{
 				empty: false, rightFinite: false, leftFinite: true,
-				leftIncluded: !i.rightIncluded, leftMoment: i.rightMoment,
+				leftIncluded: !i.rightIncluded, leftMoment: i.rightMoment, leftPrecision: i.rightPrecision,
 			},
 		}
 	}
 }
 
-// union calculates the union of intervals
+// union calculates the union of intervals.
+// Each boundary of the result is a boundary of a parameter, with its precision
 func (i interval) union(other interval) []interval {
 	if i.empty || other.isFull() {
 		return []interval{other}
@@ -547,108 +603,38 @@ func (i interval) union(other interval) []interval {
 		return []interval{i}
 	}
 
-	var joinable bool
-	var comparison int
-
-	switch {
-	case !i.leftFinite && !other.leftFinite:
-		joinable = true
-	case !i.rightFinite && !other.rightFinite:
-		joinable = true
-	case !i.leftFinite:
-		comparison = i.rightMoment.Compare(other.leftMoment)
-		if comparison < 0 {
-			joinable = false
-		} else if comparison == 0 {
-			joinable = i.rightIncluded || other.leftIncluded
-		} else {
-			joinable = true
-		}
-	case !other.leftFinite:
-		comparison = other.rightMoment.Compare(i.leftMoment)
-		if comparison < 0 {
-			joinable = false
-		} else if comparison == 0 {
-			joinable = other.rightIncluded || i.leftIncluded
-		} else {
-			joinable = true
-		}
-	case !i.rightFinite:
-		comparison = i.leftMoment.Compare(other.rightMoment)
-		if comparison > 0 {
-			joinable = false
-		} else if comparison == 0 {
-			joinable = i.leftIncluded || other.rightIncluded
-		} else {
-			joinable = true
-		}
-	case !other.rightFinite:
-		comparison = i.rightMoment.Compare(other.leftMoment)
-		if comparison < 0 {
-			joinable = false
-		} else if comparison == 0 {
-			joinable = i.leftIncluded || other.rightIncluded
-		} else {
-			joinable = true
-		}
-	default:
-		comparison = i.rightMoment.Compare(other.leftMoment)
-		if comparison < 0 {
-			joinable = false
-		} else if comparison == 0 {
-			joinable = i.rightIncluded || other.leftIncluded
-		} else {
-			comparison = i.leftMoment.Compare(other.rightMoment)
-			if comparison > 0 {
-				joinable = false
-			} else if comparison == 0 {
-				joinable = i.leftIncluded || other.rightIncluded
-			} else {
-				joinable = true
-			}
-		}
-	}
-
-	if !joinable {
+	// there is a hole between them if one ends before the other starts
+	iBeforeOther := i.rightFinite && other.leftFinite && !isConnected(i.right(), other.left())
+	otherBeforeI := other.rightFinite && i.leftFinite && !isConnected(other.right(), i.left())
+	if iBeforeOther || otherBeforeI {
 		return []interval{i, other}
 	}
 
 	// build the result getting the most extreme values
-	var minFinite, maxFinite, minIncluded, maxIncluded bool
-	var minValue, maxValue time.Time
+	result := interval{empty: false}
 	// left bound: pick the less the values
-	minFinite = i.leftFinite && other.leftFinite
-	if minFinite {
-		comparison = i.leftMoment.Compare(other.leftMoment)
-		switch {
-		case comparison < 0:
-			minIncluded, minValue = i.leftIncluded, i.leftMoment
-		case comparison > 0:
-			minIncluded, minValue = other.leftIncluded, other.leftMoment
-		default:
-			minIncluded, minValue = i.leftIncluded || other.leftIncluded, i.leftMoment
+	if i.leftFinite && other.leftFinite {
+		source := i
+		if compareLeftBounds(other.left(), i.left()) < 0 {
+			source = other
 		}
+
+		result.leftFinite = true
+		result.leftIncluded, result.leftMoment, result.leftPrecision = source.leftIncluded, source.leftMoment, source.leftPrecision
 	}
+
 	// right bound: pick the more the values
-	maxFinite = i.rightFinite && other.rightFinite
-	if maxFinite {
-		comparison = i.rightMoment.Compare(other.rightMoment)
-		switch {
-		case comparison < 0:
-			maxIncluded, maxValue = other.rightIncluded, other.rightMoment
-		case comparison > 0:
-			maxIncluded, maxValue = i.rightIncluded, i.rightMoment
-		default:
-			maxIncluded, maxValue = i.rightIncluded || other.rightIncluded, i.rightMoment
+	if i.rightFinite && other.rightFinite {
+		source := i
+		if compareRightBounds(other.right(), i.right()) > 0 {
+			source = other
 		}
+
+		result.rightFinite = true
+		result.rightIncluded, result.rightMoment, result.rightPrecision = source.rightIncluded, source.rightMoment, source.rightPrecision
 	}
 
-	// and finally, return
-	return []interval{{
-		empty:      false,
-		leftFinite: minFinite, leftIncluded: minIncluded, leftMoment: minValue,
-		rightFinite: maxFinite, rightIncluded: maxIncluded, rightMoment: maxValue,
-	}}
+	return []interval{result}
 }
 
 // intervalsUnionAll returns the union of all intervals using an optimized sweep-line algorithm (O(N log N)).
@@ -695,7 +681,7 @@ func intervalsUnionAll(intervals []interval) []interval {
 		}
 	}
 
-	// 4. Ne pas oublier de pousser le tout dernier intervalle accumulé
+	// last accumulated interval is not in result yet
 	result = append(result, current)
 
 	return result
@@ -1,6 +1,7 @@
 package periods
 
 import (
+	"errors"
 	"fmt"
 	"iter"
 	"slices"
@@ -40,6 +41,14 @@ type DynamicMapping[T any] interface {
 	DataType() string
 	// IsFunction returns true if the mapping is a function.
 	IsFunction() bool
+	// Compact merges the periods of equal values, so that each value appears once.
+	// Content, as a mapping of moments to values, is unchanged.
+	// A function that fails Validate is left as is: merging would change the value found at some moments.
+	Compact()
+	// Validate checks the function invariant: no moment maps to two different values.
+	// Values are compared with the equality of the mapping.
+	// Relations accept many values per moment, so they are always valid.
+	Validate() error
 }
 
 // ====================================================================================
@@ -63,6 +72,41 @@ func DynamicMappingCopy[T any](original DynamicMapping[T]) DynamicMapping[T] {
 	}
 }
 
+// =====================================================
+// VALIDATION OF FUNCTIONS, SHARED BY IMPLEMENTATIONS ==
+// =====================================================
+
+// CheckFunctionInvariant returns an error for each couple of different values whose periods overlap.
+// Values are compared with equals, that should be the equality of the mapping.
+// Implementations of DynamicMapping use it to validate functions.
+// Complexity: O(N^2) period intersections, N being the number of couples in values
+func CheckFunctionInvariant[T any](values iter.Seq2[Period, T], equals func(T, T) bool) error {
+	type node struct {
+		period Period
+		value  T
+	}
+
+	var nodes []node
+	for period, value := range values {
+		nodes = append(nodes, node{period: period, value: value})
+	}
+
+	var errorResult error
+	for index, element := range nodes {
+		for _, other := range nodes[index+1:] {
+			if equals(element.value, other.value) {
+				continue
+			}
+
+			if overlap := element.period.Intersection(other.period); !overlap.IsEmpty() {
+				errorResult = errors.Join(errorResult, fmt.Errorf("values %v and %v overlap during %s", element.value, other.value, overlap.AsRawString()))
+			}
+		}
+	}
+
+	return errorResult
+}
+
 // ===================================================
 // HASHING FUNCTION TO CALCULATE EQUALS AND CHANGES ==
 // ===================================================
@@ -200,6 +244,8 @@ func (vh *valuesHandler[T]) Domain() Period {
 }
 
 // first returns the first value at the given moment in time, or nil and false if no value is found.
+// If many values match (broken function invariant), the first value in storage order wins.
+// It may not be the first added one: Add stores a merged value at the end.
 func (vh *valuesHandler[T]) first(moment time.Time) (T, bool) {
 	var empty T
 	for _, element := range vh.values {
@@ -342,6 +388,39 @@ func (vh *valuesHandler[T]) Add(value T, period Period) {
 	vh.values = append(remainingValues, valueNode[T]{matchingPeriod: commonPeriod, value: value})
 }
 
+// Compact merges nodes with equal values into one node, its period being the union of their periods.
+// Order of values is their storage order, merged nodes take the place of the first one.
+// Invalid functions are left as is: first depends on storage order, so merging would change their content
+func (vh *valuesHandler[T]) Compact() {
+	if vh == nil || len(vh.values) <= 1 {
+		return
+	} else if vh.Validate() != nil {
+		return
+	}
+
+	var result []valueNode[T]
+	for _, element := range vh.values {
+		index := slices.IndexFunc(result, func(node valueNode[T]) bool { return vh.equals(node.value, element.value) })
+		if index < 0 {
+			result = append(result, element)
+		} else {
+			result[index].matchingPeriod = result[index].matchingPeriod.Union(element.matchingPeriod)
+		}
+	}
+
+	vh.values = result
+}
+
+// Validate returns an error for each couple of different values whose periods overlap, for functions only.
+// See CheckFunctionInvariant
+func (vh *valuesHandler[T]) Validate() error {
+	if vh == nil || !vh.isFunction {
+		return nil
+	}
+
+	return CheckFunctionInvariant(vh.Range(), vh.equals)
+}
+
 // isFunctionalMapping returns true if the mapping is functional or false for relational
 func (vh *valuesHandler[T]) IsFunction() bool {
 	return vh.isFunction
@@ -2,6 +2,9 @@ package periods
 
 import (
 	"errors"
+	"fmt"
+	"iter"
+	"math"
 	"slices"
 	"sort"
 	"strings"
@@ -49,7 +52,14 @@ func NewEmptyPeriod() Period {
 // NewFinitePeriod builds a period equivalent to a new finite interval (min, max)
 // SPECIAL CASES: it may return an empty period according to mathematical definition
 func NewFinitePeriod(min, max time.Time, minIncluded, maxIncluded bool) Period {
-	content := newIntervalDuring(min, max, minIncluded, maxIncluded)
+	return NewFinitePeriodWithPrecision(min, max, minIncluded, maxIncluded, TimePrecision())
+}
+
+// NewFinitePeriodWithPrecision builds a period equivalent to a new finite interval (min, max).
+// Boundaries are truncated to precision instead of the package precision.
+// SPECIAL CASES: it may return an empty period according to mathematical definition
+func NewFinitePeriodWithPrecision(min, max time.Time, minIncluded, maxIncluded bool, precision time.Duration) Period {
+	content := newIntervalDuring(min, max, minIncluded, maxIncluded, precision)
 	if content.empty {
 		return Period{}
 	} else {
@@ -59,12 +69,22 @@ func NewFinitePeriod(min, max time.Time, minIncluded, maxIncluded bool) Period {
 
 // NewPeriodSince builds a period equivalent to (leftLimit, +oo[
 func NewPeriodSince(leftLimit time.Time, leftIn bool) Period {
-	return Period{intervals: []interval{newIntervalSince(leftLimit, leftIn)}}
+	return NewPeriodSinceWithPrecision(leftLimit, leftIn, TimePrecision())
+}
+
+// NewPeriodSinceWithPrecision builds a period equivalent to (leftLimit, +oo[, leftLimit truncated to precision
+func NewPeriodSinceWithPrecision(leftLimit time.Time, leftIn bool, precision time.Duration) Period {
+	return Period{intervals: []interval{newIntervalSince(leftLimit, leftIn, precision)}}
 }
 
 // NewPeriodUntil builds a period equivalent to ]-oo,rightLimit)
 func NewPeriodUntil(rightLimit time.Time, rightIn bool) Period {
-	content := newIntervalUntil(rightLimit, rightIn)
+	return NewPeriodUntilWithPrecision(rightLimit, rightIn, TimePrecision())
+}
+
+// NewPeriodUntilWithPrecision builds a period equivalent to ]-oo,rightLimit), rightLimit truncated to precision
+func NewPeriodUntilWithPrecision(rightLimit time.Time, rightIn bool, precision time.Duration) Period {
+	content := newIntervalUntil(rightLimit, rightIn, precision)
 	return Period{intervals: []interval{content}}
 }
 
@@ -107,6 +127,73 @@ func (p Period) Union(other Period) Period {
 	return Period{intervals: result}
 }
 
+// SymmetricDifference returns the moments that belong to exactly one of p and other.
+// It is (p union other) minus (p inter other), with intervals unioned to keep a canonical form
+func (p Period) SymmetricDifference(other Period) Period {
+	remaining := p.Union(other).Remove(p.Intersection(other))
+	return Period{intervals: intervalsUnionAll(remaining.intervals)}
+}
+
+// IntersectAll returns the intersection of all values, computed on intervals directly.
+// With no value, it returns the full period (neutral element for intersection)
+func IntersectAll(values ...Period) Period {
+	if len(values) == 0 {
+		return NewFullPeriod()
+	}
+
+	current := values[0].intervals
+	for _, value := range values[1:] {
+		if len(current) == 0 {
+			break
+		}
+
+		var next []interval
+		for _, source := range current {
+			for _, other := range value.intervals {
+				if result := intervalsIntersection([]interval{source, other}); !result.empty {
+					next = append(next, result)
+				}
+			}
+		}
+
+		current = next
+	}
+
+	if len(current) == 0 {
+		return Period{}
+	}
+
+	return Period{intervals: intervalsUnionAll(current)}
+}
+
+// UnionAll returns the union of all values, merging all their intervals at once.
+// With no value, it returns the empty period (neutral element for union)
+func UnionAll(values ...Period) Period {
+	var elements []interval
+	for _, value := range values {
+		elements = append(elements, value.intervals...)
+	}
+
+	var result []interval
+	for _, value := range intervalsUnionAll(elements) {
+		if !value.empty {
+			result = append(result, value)
+		}
+	}
+
+	if len(result) == 0 {
+		return Period{}
+	}
+
+	return Period{intervals: result}
+}
+
+// TotalActiveDuration returns the duration of the union of values, so that overlaps count once.
+// It returns false if that union is infinite. As for Duration, result is at most math.MaxInt64
+func TotalActiveDuration(values []Period) (time.Duration, bool) {
+	return UnionAll(values...).Duration()
+}
+
 // Equals returns true if periods have the same content
 func (p Period) Equals(other Period) bool {
 	if len(p.intervals) != len(other.intervals) {
@@ -169,6 +256,12 @@ func (p Period) IsIncludedIn(other Period) bool {
 	return true
 }
 
+// ContainsPeriod returns true if other is included in p.
+// Empty period is contained by any period, and contains no period but itself
+func (p Period) ContainsPeriod(other Period) bool {
+	return other.IsIncludedIn(p)
+}
+
 // Complement returns the complement of the period,
 // that is the other period that forms a partition of full space with others
 func (p Period) Complement() Period {
@@ -181,7 +274,10 @@ func (p Period) Complement() Period {
 	var previousFinite, previousIncluded bool
 	// sort matters to manage holes
 	sortedIntervals := sortIntervals(p.intervals)
-	// using the "completing hole" method: find all intervals so that the union would make full
+	// using the "completing hole" method: find all intervals so that the union would make full.
+	// Boundaries come from existing intervals, so they are already truncated.
+	// Each boundary of a hole is a boundary of an interval, with its inclusion flipped and its precision kept.
+	var previousPrecision time.Duration
 	for index, value := range sortedIntervals {
 		if value.isFull() {
 			return Period{}
@@ -191,25 +287,28 @@ func (p Period) Complement() Period {
 			// may complete left
 			if value.leftFinite {
 				// left completion
-				completion := newIntervalUntil(value.leftMoment, !value.leftIncluded)
-				result = append(result, completion)
+				result = append(result, newIntervalUntil(value.leftMoment, !value.leftIncluded, value.leftPrecision))
 			}
 		} else {
 			// complete from previous to value
-			completion := newIntervalDuring(previousValue, value.leftMoment, !previousIncluded, !value.leftIncluded)
+			completion := buildIntervalWithPrecisions(false, true, true,
+				previousValue, value.leftMoment,
+				!previousIncluded, !value.leftIncluded,
+				previousPrecision, value.leftPrecision,
+			)
+
 			if !completion.empty {
 				result = append(result, completion)
 			}
 		}
 
 		previousFinite, previousIncluded = value.rightFinite, value.rightIncluded
-		previousValue = value.rightMoment
+		previousValue, previousPrecision = value.rightMoment, value.rightPrecision
 	}
 
 	if previousFinite {
 		// complete to reach +oo
-		completion := newIntervalSince(previousValue, !previousIncluded)
-		result = append(result, completion)
+		result = append(result, newIntervalSince(previousValue, !previousIncluded, previousPrecision))
 	}
 
 	// result contains the partition that completes the initial period
@@ -302,11 +401,387 @@ func (p Period) BoundingPeriod() Period {
 	size := len(p.intervals)
 	minInterval := p.intervals[0]
 	maxInterval := p.intervals[size-1]
-	result := buildInterval(false,
+	result := buildIntervalWithPrecisions(false,
 		minInterval.leftFinite, maxInterval.rightFinite,
 		minInterval.leftMoment, maxInterval.rightMoment,
 		minInterval.leftIncluded, maxInterval.rightIncluded,
+		minInterval.leftPrecision, maxInterval.rightPrecision,
 	)
 
 	return Period{intervals: []interval{result}}
 }
+
+// Shift returns the period translated by delta: each finite boundary is moved by delta.
+// Inclusion flags and infinite sides are preserved, so empty and full periods are unchanged.
+// Moved boundaries are truncated to the precision of their interval.
+func (p Period) Shift(delta time.Duration) Period {
+	if len(p.intervals) == 0 {
+		return Period{}
+	}
+
+	result := make([]interval, 0, len(p.intervals))
+	for _, value := range p.intervals {
+		if shifted := value.shift(delta); !shifted.empty {
+			result = append(result, shifted)
+		}
+	}
+
+	return Period{intervals: result}
+}
+
+// Extend returns the period where each interval starts left earlier and ends right later.
+// Infinite sides stay infinite, so empty and full periods are unchanged.
+// Intervals that now overlap are merged, intervals shrunk to nothing (negative durations) are removed.
+// Moved boundaries are truncated to the precision of their interval, so Extend(0, 0) returns the same period.
+func (p Period) Extend(left, right time.Duration) Period {
+	if len(p.intervals) == 0 {
+		return Period{}
+	}
+
+	result := make([]interval, 0, len(p.intervals))
+	for _, value := range p.intervals {
+		if extended := value.extend(left, right); !extended.empty {
+			result = append(result, extended)
+		}
+	}
+
+	if len(result) == 0 {
+		return Period{}
+	}
+
+	return Period{intervals: intervalsUnionAll(result)}
+}
+
+// Sample iterates over moments of the period, starting at its earliest left boundary and moving by step.
+// Moments that the period does not contain are skipped.
+// Iteration stops after the last finite right boundary, so it never ends for a period unbounded on the right.
+// SPECIAL CASES: a period with no finite left boundary, or a step that is not positive, yields nothing.
+func (p Period) Sample(step time.Duration) iter.Seq[time.Time] {
+	if len(p.intervals) == 0 || step <= 0 {
+		return func(yield func(time.Time) bool) {}
+	}
+
+	sorted := sortIntervals(p.intervals)
+	first := sorted[0]
+	if !first.leftFinite {
+		return func(yield func(time.Time) bool) {}
+	}
+
+	// last interval in sort order may not hold the maximum right boundary
+	rightFinite := true
+	var rightMoment time.Time
+	for _, value := range sorted {
+		if !value.rightFinite {
+			rightFinite = false
+			break
+		} else if value.rightMoment.After(rightMoment) {
+			rightMoment = value.rightMoment
+		}
+	}
+
+	return func(yield func(time.Time) bool) {
+		for moment := first.leftMoment; !rightFinite || !moment.After(rightMoment); moment = moment.Add(step) {
+			if p.Contains(moment) && !yield(moment) {
+				return
+			}
+		}
+	}
+}
+
+// NearestBoundary returns the finite boundary of the period closest to moment.
+// All intervals of the period are considered, not just its bounding period.
+// On ties, a left boundary wins over a right boundary, then the earliest boundary wins.
+// SPECIAL CASES: it returns false when the period is empty or has no finite boundary
+func (p Period) NearestBoundary(moment time.Time) (time.Time, bool) {
+	var result time.Time
+	var resultIsLeft, found bool
+	var bestDistance time.Duration
+
+	// distance returns the absolute duration between moment and value
+	distance := func(value time.Time) time.Duration {
+		if value.Before(moment) {
+			return moment.Sub(value)
+		}
+
+		return value.Sub(moment)
+	}
+
+	// candidate replaces result if value is strictly better
+	candidate := func(value time.Time, isLeft bool) {
+		current := distance(value)
+		var better bool
+		switch {
+		case !found || current < bestDistance:
+			better = true
+		case current > bestDistance:
+			better = false
+		case isLeft != resultIsLeft:
+			better = isLeft
+		default:
+			better = value.Before(result)
+		}
+
+		if better {
+			result, resultIsLeft, bestDistance, found = value, isLeft, current, true
+		}
+	}
+
+	for _, value := range p.intervals {
+		if value.leftFinite {
+			candidate(value.leftMoment, true)
+		}
+
+		if value.rightFinite {
+			candidate(value.rightMoment, false)
+		}
+	}
+
+	return result, found
+}
+
+// Bounds returns the boundaries of the bounding period: earliest left boundary and latest right boundary.
+// Flags are false for infinite sides, and moments are then zero time.
+// SPECIAL CASE: both flags are false for the empty period too, use IsEmpty to distinguish it from the full period
+func (p Period) Bounds() (start time.Time, startFinite bool, end time.Time, endFinite bool) {
+	if len(p.intervals) == 0 {
+		return
+	}
+
+	startFinite, endFinite = true, true
+	for index, value := range p.intervals {
+		if !value.leftFinite {
+			startFinite, start = false, time.Time{}
+		} else if startFinite && (index == 0 || value.leftMoment.Before(start)) {
+			start = value.leftMoment
+		}
+
+		if !value.rightFinite {
+			endFinite, end = false, time.Time{}
+		} else if endFinite && (index == 0 || value.rightMoment.After(end)) {
+			end = value.rightMoment
+		}
+	}
+
+	return
+}
+
+// CoverageRatio returns the part of within that p covers, as a number between 0 and 1.
+// It returns false if within is not bounded, and 0 for an empty within.
+// Unlike Duration, it has no maximum: it is valid for periods of any length
+func (p Period) CoverageRatio(within Period) (float64, bool) {
+	total, finite := within.nanoseconds()
+	if !finite {
+		return 0, false
+	} else if total == 0 {
+		return 0, true
+	}
+
+	covered, _ := p.Intersection(within).nanoseconds()
+	return covered / total, true
+}
+
+// BoundaryMoments returns all finite boundaries of the period, sorted and without duplicates
+func (p Period) BoundaryMoments() []time.Time {
+	var result []time.Time
+	for _, value := range p.intervals {
+		if value.leftFinite {
+			result = append(result, value.leftMoment)
+		}
+
+		if value.rightFinite {
+			result = append(result, value.rightMoment)
+		}
+	}
+
+	slices.SortFunc(result, time.Time.Compare)
+	return slices.CompactFunc(result, time.Time.Equal)
+}
+
+// Duration returns the total length of the period, sum of the lengths of its intervals.
+// It returns false if the period is infinite (empty period has a zero duration).
+// SPECIAL CASE: time.Duration cannot exceed about 292 years,
+// so a longer period returns the maximum duration, math.MaxInt64, and true
+func (p Period) Duration() (time.Duration, bool) {
+	var result time.Duration
+	// intervals of a period should not overlap, union ensures we do not count twice
+	for _, value := range intervalsUnionAll(p.intervals) {
+		if !value.leftFinite || !value.rightFinite {
+			return 0, false
+		}
+
+		// Sub already saturates to the maximum duration
+		length := value.rightMoment.Sub(value.leftMoment)
+		if length == math.MaxInt64 || result > math.MaxInt64-length {
+			result = math.MaxInt64
+		} else {
+			result += length
+		}
+	}
+
+	return result, true
+}
+
+// nanoseconds returns the total length of the period in nanoseconds, as Duration does, but with no maximum.
+// It returns false if the period is infinite
+func (p Period) nanoseconds() (float64, bool) {
+	var result float64
+	for _, value := range intervalsUnionAll(p.intervals) {
+		if !value.leftFinite || !value.rightFinite {
+			return 0, false
+		}
+
+		seconds := value.rightMoment.Unix() - value.leftMoment.Unix()
+		nanos := value.rightMoment.Nanosecond() - value.leftMoment.Nanosecond()
+		result += float64(seconds)*float64(time.Second) + float64(nanos)
+	}
+
+	return result, true
+}
+
+// OverlapDuration returns the duration of the intersection of p and other.
+// It returns false if that intersection is infinite, and 0 for disjoint periods.
+// As for Duration, result is at most math.MaxInt64
+func (p Period) OverlapDuration(other Period) (time.Duration, bool) {
+	return p.Intersection(other).Duration()
+}
+
+// ScaleAround returns the period with each boundary offset from pivot multiplied by factor.
+// Factor more than 1 stretches the period, less than 1 compresses it, negative values mirror it around pivot.
+// Boundaries are truncated to the precision of their interval.
+// It returns false if the period is not bounded (empty period is bounded)
+func (p Period) ScaleAround(pivot time.Time, factor float64) (Period, bool) {
+	// scale moves a moment away from pivot
+	scale := func(moment time.Time) time.Time {
+		offset := moment.Sub(pivot)
+		return pivot.Add(time.Duration(float64(offset) * factor))
+	}
+
+	var result []interval
+	for _, value := range p.intervals {
+		if !value.leftFinite || !value.rightFinite {
+			return Period{}, false
+		}
+
+		left, right := scale(value.leftMoment), scale(value.rightMoment)
+		leftIn, rightIn := value.leftIncluded, value.rightIncluded
+		leftPrecision, rightPrecision := value.leftPrecision, value.rightPrecision
+		if factor < 0 {
+			left, right = right, left
+			leftIn, rightIn = rightIn, leftIn
+			leftPrecision, rightPrecision = rightPrecision, leftPrecision
+		}
+
+		scaled := buildIntervalWithPrecisions(false, true, true, left, right, leftIn, rightIn, leftPrecision, rightPrecision)
+		if !scaled.empty {
+			result = append(result, scaled)
+		}
+	}
+
+	if len(result) == 0 {
+		return Period{}, true
+	}
+
+	return Period{intervals: intervalsUnionAll(result)}, true
+}
+
+// PeriodInterval is a read-only view of one interval of a period.
+// Boundaries are meaningful only when finite.
+type PeriodInterval struct {
+	// value is the underlying interval
+	value interval
+	// valid is true for intervals built by this package, to reject zero values
+	valid bool
+}
+
+// IsLeftFinite returns true if left boundary is finite
+func (pi PeriodInterval) IsLeftFinite() bool {
+	return pi.value.leftFinite
+}
+
+// IsRightFinite returns true if right boundary is finite
+func (pi PeriodInterval) IsRightFinite() bool {
+	return pi.value.rightFinite
+}
+
+// IsLeftIncluded returns true if left boundary is finite and included
+func (pi PeriodInterval) IsLeftIncluded() bool {
+	return pi.value.leftIncluded
+}
+
+// IsRightIncluded returns true if right boundary is finite and included
+func (pi PeriodInterval) IsRightIncluded() bool {
+	return pi.value.rightIncluded
+}
+
+// LeftMoment returns the left boundary, zero time if not finite
+func (pi PeriodInterval) LeftMoment() time.Time {
+	return pi.value.leftMoment
+}
+
+// RightMoment returns the right boundary, zero time if not finite
+func (pi PeriodInterval) RightMoment() time.Time {
+	return pi.value.rightMoment
+}
+
+// LeftPrecision returns the precision left boundary was truncated to, 0 if not truncated or not finite
+func (pi PeriodInterval) LeftPrecision() time.Duration {
+	return pi.value.leftPrecision
+}
+
+// RightPrecision returns the precision right boundary was truncated to, 0 if not truncated or not finite
+func (pi PeriodInterval) RightPrecision() time.Duration {
+	return pi.value.rightPrecision
+}
+
+// NewPeriodInterval builds an interval to use in NewPeriodFromIntervals.
+// Moments are ignored for infinite sides, finite ones are truncated to current precision.
+// It raises an error if the interval would be empty
+func NewPeriodInterval(leftFinite bool, left time.Time, leftIncluded bool, rightFinite bool, right time.Time, rightIncluded bool) (PeriodInterval, error) {
+	return NewPeriodIntervalWithPrecision(leftFinite, left, leftIncluded, rightFinite, right, rightIncluded, TimePrecision())
+}
+
+// NewPeriodIntervalWithPrecision builds an interval to use in NewPeriodFromIntervals.
+// Moments are ignored for infinite sides, finite ones are truncated to precision (0 keeps them as is).
+// It raises an error if the interval would be empty
+func NewPeriodIntervalWithPrecision(leftFinite bool, left time.Time, leftIncluded bool, rightFinite bool, right time.Time, rightIncluded bool, precision time.Duration) (PeriodInterval, error) {
+	value := buildInterval(false, leftFinite, rightFinite, left, right, leftIncluded, rightIncluded, precision)
+	if value.empty {
+		return PeriodInterval{}, errors.New("interval would be empty")
+	}
+
+	return PeriodInterval{value: value, valid: true}, nil
+}
+
+// Intervals returns the intervals of the period, sorted and disjoint.
+// Empty period returns an empty slice, full period returns one unbounded interval
+func (p Period) Intervals() []PeriodInterval {
+	result := make([]PeriodInterval, 0, len(p.intervals))
+	for _, value := range sortIntervals(p.intervals) {
+		result = append(result, PeriodInterval{value: value, valid: true})
+	}
+
+	return result
+}
+
+// NewPeriodFromIntervals builds the period as the union of intervals.
+// Overlapping intervals are merged.
+// It raises an error if an interval was not built by this package (zero value for instance)
+func NewPeriodFromIntervals(intervals []PeriodInterval) (Period, error) {
+	var errorResult error
+	var elements []interval
+	for index, value := range intervals {
+		if !value.valid {
+			errorResult = errors.Join(errorResult, fmt.Errorf("invalid interval at index %d", index))
+		} else {
+			elements = append(elements, value.value)
+		}
+	}
+
+	if errorResult != nil {
+		return Period{}, errorResult
+	} else if len(elements) == 0 {
+		return Period{}, nil
+	}
+
+	return Period{intervals: intervalsUnionAll(elements)}, nil
+}
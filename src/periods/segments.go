@@ -0,0 +1,117 @@
+package periods
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PeriodSegment is an explicit, serialization friendly form of an interval of a period.
+// Times are RFC3339 strings, an empty string means an infinite side (and then, inclusion flag is ignored).
+// For instance, [2024-01-01T00:00:00Z, +oo[ is {Start: "2024-01-01T00:00:00Z", StartIncluded: true}.
+type PeriodSegment struct {
+	// Start is the left boundary, empty for -oo
+	Start string `json:"start,omitempty"`
+	// StartIncluded is true if Start belongs to the segment
+	StartIncluded bool `json:"startIncluded"`
+	// End is the right boundary, empty for +oo
+	End string `json:"end,omitempty"`
+	// EndIncluded is true if End belongs to the segment
+	EndIncluded bool `json:"endIncluded"`
+	// StartPrecision is the precision Start is truncated to, as a duration string (for instance "1ms").
+	// Empty means the package precision, "0s" means no truncation
+	StartPrecision string `json:"startPrecision,omitempty"`
+	// EndPrecision is the precision End is truncated to, same format as StartPrecision
+	EndPrecision string `json:"endPrecision,omitempty"`
+}
+
+// AsStructured returns the period as sorted and disjoint segments.
+// Empty period returns an empty slice, full period returns one segment with no boundary.
+// Moments are written with nanoseconds and each boundary holds its precision, so that any precision survives a round trip.
+func (p Period) AsStructured() []PeriodSegment {
+	intervals := p.Intervals()
+	result := make([]PeriodSegment, 0, len(intervals))
+	for _, value := range intervals {
+		var segment PeriodSegment
+		if value.IsLeftFinite() {
+			segment.Start = value.LeftMoment().Format(time.RFC3339Nano)
+			segment.StartIncluded = value.IsLeftIncluded()
+			segment.StartPrecision = value.LeftPrecision().String()
+		}
+
+		if value.IsRightFinite() {
+			segment.End = value.RightMoment().Format(time.RFC3339Nano)
+			segment.EndIncluded = value.IsRightIncluded()
+			segment.EndPrecision = value.RightPrecision().String()
+		}
+
+		result = append(result, segment)
+	}
+
+	return result
+}
+
+// PeriodFromStructured builds the period as the union of segments.
+// Segments may overlap, they are merged.
+// It raises an error if a moment is not a RFC3339 time, if a precision is not a duration,
+// or if a segment is empty (start after end, for instance). Errors are cumulative.
+func PeriodFromStructured(segments []PeriodSegment) (Period, error) {
+	var errorResult error
+	intervals := make([]PeriodInterval, 0, len(segments))
+	for index, segment := range segments {
+		var left, right time.Time
+		var segmentError error
+		leftFinite := segment.Start != ""
+		rightFinite := segment.End != ""
+		leftPrecision, rightPrecision := TimePrecision(), TimePrecision()
+		if leftFinite {
+			if value, err := time.Parse(time.RFC3339Nano, segment.Start); err != nil {
+				segmentError = errors.Join(segmentError, fmt.Errorf("invalid start at index %d: %w", index, err))
+			} else {
+				left = value
+			}
+		}
+
+		if rightFinite {
+			if value, err := time.Parse(time.RFC3339Nano, segment.End); err != nil {
+				segmentError = errors.Join(segmentError, fmt.Errorf("invalid end at index %d: %w", index, err))
+			} else {
+				right = value
+			}
+		}
+
+		if segment.StartPrecision != "" {
+			if value, err := time.ParseDuration(segment.StartPrecision); err != nil {
+				segmentError = errors.Join(segmentError, fmt.Errorf("invalid start precision at index %d: %w", index, err))
+			} else {
+				leftPrecision = value
+			}
+		}
+
+		if segment.EndPrecision != "" {
+			if value, err := time.ParseDuration(segment.EndPrecision); err != nil {
+				segmentError = errors.Join(segmentError, fmt.Errorf("invalid end precision at index %d: %w", index, err))
+			} else {
+				rightPrecision = value
+			}
+		}
+
+		if segmentError != nil {
+			errorResult = errors.Join(errorResult, segmentError)
+			continue
+		}
+
+		value := buildIntervalWithPrecisions(false, leftFinite, rightFinite, left, right, segment.StartIncluded, segment.EndIncluded, leftPrecision, rightPrecision)
+		if value.empty {
+			errorResult = errors.Join(errorResult, fmt.Errorf("invalid segment at index %d: interval would be empty", index))
+		} else {
+			intervals = append(intervals, PeriodInterval{value: value, valid: true})
+		}
+	}
+
+	if errorResult != nil {
+		return Period{}, errorResult
+	}
+
+	return NewPeriodFromIntervals(intervals)
+}
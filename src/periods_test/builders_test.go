@@ -0,0 +1,84 @@
+package periods_test
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/zefrenchwan/perspectives.git/periods"
+)
+
+func TestPeriodBuilder(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	builder := periods.NewPeriodBuilder()
+	if !builder.Build().IsEmpty() {
+		t.Log("empty builder should build empty period")
+		t.Fail()
+	}
+
+	builder.Add(now, now.Add(time.Hour), true, false)
+	builder.Add(now.Add(2*time.Hour), now.Add(time.Hour), true, true)
+	builder.AddPeriod(periods.NewPeriodSince(now.Add(time.Hour), true))
+	expected := periods.NewPeriodSince(now, true)
+	if res := builder.Build(); !res.Equals(expected) {
+		t.Logf("expected %s, got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	}
+}
+
+func TestPeriodBuilderMatchesUnions(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	random := rand.New(rand.NewSource(42))
+	for range 50 {
+		builder := periods.NewPeriodBuilder()
+		expected := periods.NewEmptyPeriod()
+		for range 100 {
+			start := now.Add(time.Duration(random.Intn(1000)) * time.Minute)
+			end := start.Add(time.Duration(random.Intn(30)) * time.Minute)
+			startIn, endIn := random.Intn(2) == 0, random.Intn(2) == 0
+			builder.Add(start, end, startIn, endIn)
+			expected = expected.Union(periods.NewFinitePeriod(start, end, startIn, endIn))
+		}
+
+		if res := builder.Build(); !res.Equals(expected) {
+			t.Logf("expected %s, got %s", expected.AsRawString(), res.AsRawString())
+			t.FailNow()
+		}
+	}
+}
+
+// ingestionIntervals returns count small intervals, in random order
+func ingestionIntervals(count int) []time.Time {
+	now := time.Now().Truncate(time.Hour)
+	random := rand.New(rand.NewSource(42))
+	result := make([]time.Time, count)
+	for index := range count {
+		result[index] = now.Add(time.Duration(random.Intn(10*count)) * time.Minute)
+	}
+
+	return result
+}
+
+func BenchmarkPeriodBuilderIngestion(b *testing.B) {
+	starts := ingestionIntervals(10000)
+	b.ResetTimer()
+	for range b.N {
+		builder := periods.NewPeriodBuilder()
+		for _, start := range starts {
+			builder.Add(start, start.Add(5*time.Minute), true, false)
+		}
+
+		builder.Build()
+	}
+}
+
+func BenchmarkPeriodUnionIngestion(b *testing.B) {
+	starts := ingestionIntervals(10000)
+	b.ResetTimer()
+	for range b.N {
+		result := periods.NewEmptyPeriod()
+		for _, start := range starts {
+			result = result.Union(periods.NewFinitePeriod(start, start.Add(5*time.Minute), true, false))
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package periods_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zefrenchwan/perspectives.git/periods"
+)
+
+// brokenFunction is a function that does not keep its invariant: values may overlap
+type brokenFunction struct {
+	periods.DynamicRelation[int]
+}
+
+func (b brokenFunction) IsFunction() bool {
+	return true
+}
+
+func (b brokenFunction) Validate() error {
+	return periods.CheckFunctionInvariant(b.Range(), func(a, b int) bool { return a == b })
+}
+
+func TestDynamicMappingValidate(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	equals := func(a, b int) bool { return a == b }
+
+	// relations accept overlapping values
+	relation := periods.NewTimeRelation("int", equals)
+	relation.Add(1, periods.NewPeriodUntil(now.Add(time.Hour), true))
+	relation.Add(2, periods.NewPeriodSince(now, true))
+	if err := relation.Validate(); err != nil {
+		t.Errorf("relation accepts overlaps, got %s", err.Error())
+	}
+
+	// functions keep their invariant, whatever the operations
+	function := periods.NewTimeFunction("int", equals)
+	function.Add(1, periods.NewPeriodUntil(now.Add(time.Hour), true))
+	function.Add(2, periods.NewPeriodSince(now, true))
+	function.Add(1, periods.NewFinitePeriod(now.Add(2*time.Hour), now.Add(3*time.Hour), true, false))
+	function.Remove(periods.NewFinitePeriod(now.Add(-time.Hour), now, true, false))
+	function.Add(3, periods.NewFinitePeriod(now.Add(-2*time.Hour), now.Add(4*time.Hour), false, false))
+	function.Compact()
+	if err := function.Validate(); err != nil {
+		t.Errorf("function should be valid, got %s", err.Error())
+	}
+
+	// equality of the mapping is used: values equal modulo 10 are the same
+	modulo := periods.NewTimeFunction("int", func(a, b int) bool { return a%10 == b%10 })
+	modulo.Add(1, periods.NewPeriodUntil(now.Add(time.Hour), true))
+	modulo.Add(11, periods.NewPeriodSince(now, true))
+	if err := modulo.Validate(); err != nil {
+		t.Errorf("function should be valid with its own equality, got %s", err.Error())
+	} else if value, found := modulo.At(now.Add(-time.Hour)); !found || value != 11 {
+		t.Errorf("equal values should be merged, got %d", value)
+	}
+
+	// overlapping different values are reported, equal ones are not
+	var broken periods.DynamicMapping[int] = brokenFunction{periods.NewTimeRelation("int", equals)}
+	broken.Add(1, periods.NewPeriodUntil(now.Add(time.Hour), true))
+	broken.Add(2, periods.NewPeriodSince(now, true))
+	broken.Add(1, periods.NewPeriodSince(now, true))
+	if err := broken.Validate(); err == nil {
+		t.Error("overlapping values should raise an error")
+	} else if message := err.Error(); strings.Count(message, "overlap") != 2 {
+		t.Errorf("expected one error per couple of different values, got %s", message)
+	} else if !strings.Contains(message, "values 1 and 2") || !strings.Contains(message, "values 2 and 1") {
+		t.Errorf("error should name the values, got %s", message)
+	}
+}
+
+func TestDynamicMappingCompact(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	relation := periods.NewTimeRelation("int", func(a, b int) bool { return a == b })
+	relation.Add(1, periods.NewFinitePeriod(now, now.Add(time.Hour), true, false))
+	relation.Add(2, periods.NewPeriodSince(now, true))
+	relation.Add(1, periods.NewFinitePeriod(now.Add(time.Hour), now.Add(2*time.Hour), true, false))
+	relation.Add(1, periods.NewFinitePeriod(now.Add(3*time.Hour), now.Add(4*time.Hour), true, false))
+	domain := relation.Domain()
+
+	relation.Compact()
+	counter := 0
+	expected := periods.NewFinitePeriod(now, now.Add(2*time.Hour), true, false).
+		Union(periods.NewFinitePeriod(now.Add(3*time.Hour), now.Add(4*time.Hour), true, false))
+	for period, value := range relation.Range() {
+		counter++
+		if value == 1 && !period.Equals(expected) {
+			t.Errorf("expected %s for 1, got %s", expected.AsRawString(), period.AsRawString())
+		}
+	}
+
+	if counter != 2 {
+		t.Errorf("expected one node per value, got %d", counter)
+	} else if !relation.Domain().Equals(domain) {
+		t.Error("compact should not change the domain")
+	}
+}
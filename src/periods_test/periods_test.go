@@ -1,6 +1,7 @@
 package periods_test
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -558,3 +559,800 @@ func TestBoundingPeriodEdgeCases(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestPeriodShift(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	week := 7 * 24 * time.Hour
+	before := now.Add(-2 * time.Hour)
+	after := now.Add(2 * time.Hour)
+
+	// finite and infinite parts are moved, inclusion flags are kept
+	value := periods.NewFinitePeriod(before, now, true, false).Union(periods.NewPeriodSince(after, false))
+	expected := periods.NewFinitePeriod(before.Add(week), now.Add(week), true, false).Union(periods.NewPeriodSince(after.Add(week), false))
+	if res := value.Shift(week); !res.Equals(expected) {
+		t.Logf("shift failed, expected %s got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	}
+
+	// going back in time
+	expected = periods.NewPeriodUntil(before, true)
+	if res := periods.NewPeriodUntil(now, true).Shift(-2 * time.Hour); !res.Equals(expected) {
+		t.Logf("negative shift failed, expected %s got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	}
+
+	// empty and full are unchanged
+	if !periods.NewEmptyPeriod().Shift(week).IsEmpty() {
+		t.Log("shift of empty should be empty")
+		t.Fail()
+	} else if !periods.NewFullPeriod().Shift(week).Equals(periods.NewFullPeriod()) {
+		t.Log("shift of full should be full")
+		t.Fail()
+	}
+
+	// truncation applies to shifted boundaries
+	expected = periods.NewPeriodSince(now.Add(time.Second), true)
+	if res := periods.NewPeriodSince(now, true).Shift(1500 * time.Millisecond); !res.Equals(expected) {
+		t.Logf("shift should truncate, expected %s got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	}
+}
+
+func TestPeriodShiftRoundTrip(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	// disjoint intervals one second apart must remain disjoint
+	value := periods.NewFinitePeriod(now, now.Add(time.Second), true, false).
+		Union(periods.NewFinitePeriod(now.Add(time.Second), now.Add(time.Hour), false, true)).
+		Union(periods.NewPeriodSince(now.Add(2*time.Hour), true))
+
+	for _, delta := range []time.Duration{365 * 24 * time.Hour, -30 * time.Minute, 3 * time.Second} {
+		shifted := value.Shift(delta)
+		if len(shifted.AsStrings()) != len(value.AsStrings()) {
+			t.Errorf("shift by %v should not merge intervals, got %s", delta, shifted.AsRawString())
+		} else if res := shifted.Shift(-delta); !res.Equals(value) {
+			t.Errorf("shift by %v then back should be original, got %s", delta, res.AsRawString())
+		}
+	}
+}
+
+func TestPeriodExtend(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	day := 24 * time.Hour
+
+	// extend the end by 30 days
+	value := periods.NewFinitePeriod(now, now.Add(day), true, false)
+	expected := periods.NewFinitePeriod(now, now.Add(31*day), true, false)
+	if res := value.Extend(0, 30*day); !res.Equals(expected) {
+		t.Errorf("extend failed, expected %s got %s", expected.AsRawString(), res.AsRawString())
+	}
+
+	// infinite sides stay infinite, each interval is extended
+	value = periods.NewPeriodUntil(now, true).Union(periods.NewPeriodSince(now.Add(10*day), false))
+	expected = periods.NewPeriodUntil(now.Add(day), true).Union(periods.NewPeriodSince(now.Add(8*day), false))
+	if res := value.Extend(2*day, day); !res.Equals(expected) {
+		t.Errorf("extend failed, expected %s got %s", expected.AsRawString(), res.AsRawString())
+	}
+
+	// overlapping intervals are merged
+	value = periods.NewFinitePeriod(now, now.Add(day), true, true).
+		Union(periods.NewFinitePeriod(now.Add(2*day), now.Add(3*day), true, true))
+	expected = periods.NewFinitePeriod(now.Add(-day), now.Add(4*day), true, true)
+	if res := value.Extend(day, day); !res.Equals(expected) {
+		t.Errorf("extend should merge, expected %s got %s", expected.AsRawString(), res.AsRawString())
+	}
+
+	// shrinking removes too small intervals
+	expected = periods.NewFinitePeriod(now.Add(2*day+time.Hour), now.Add(3*day-time.Hour), true, true)
+	value = periods.NewFinitePeriod(now, now.Add(time.Hour), true, true).
+		Union(periods.NewFinitePeriod(now.Add(2*day), now.Add(3*day), true, true))
+	if res := value.Extend(-time.Hour, -time.Hour); !res.Equals(expected) {
+		t.Errorf("shrink failed, expected %s got %s", expected.AsRawString(), res.AsRawString())
+	}
+
+	// empty and full are unchanged
+	if !periods.NewEmptyPeriod().Extend(day, day).IsEmpty() {
+		t.Error("extend of empty should be empty")
+	} else if !periods.NewFullPeriod().Extend(-day, -day).Equals(periods.NewFullPeriod()) {
+		t.Error("extend of full should be full")
+	}
+}
+
+func TestPeriodSample(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	// [now, now+2h[ U [now+3h, now+4h]
+	value := periods.NewFinitePeriod(now, now.Add(2*time.Hour), true, false).
+		Union(periods.NewFinitePeriod(now.Add(3*time.Hour), now.Add(4*time.Hour), true, true))
+
+	var result []time.Time
+	for moment := range value.Sample(time.Hour) {
+		result = append(result, moment)
+	}
+
+	expected := []time.Time{now, now.Add(time.Hour), now.Add(3 * time.Hour), now.Add(4 * time.Hour)}
+	if len(result) != len(expected) {
+		t.Logf("expected %d samples, got %v", len(expected), result)
+		t.FailNow()
+	}
+
+	for index, moment := range expected {
+		if !result[index].Equal(moment) {
+			t.Logf("sample %d: expected %s got %s", index, moment, result[index])
+			t.Fail()
+		}
+	}
+
+	// no finite start or invalid step means no sample
+	for range periods.NewPeriodUntil(now, true).Sample(time.Hour) {
+		t.Log("period with no left boundary should yield nothing")
+		t.Fail()
+	}
+
+	for range value.Sample(0) {
+		t.Log("zero step should yield nothing")
+		t.Fail()
+	}
+
+	// infinite on the right: consumer decides when to stop
+	counter := 0
+	for range periods.NewPeriodSince(now, false).Sample(time.Minute) {
+		counter++
+		if counter == 10 {
+			break
+		}
+	}
+
+	if counter != 10 {
+		t.Logf("expected to iterate over unbounded period, got %d values", counter)
+		t.Fail()
+	}
+}
+
+func TestPeriodTimePrecision(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	first := now.Add(100 * time.Millisecond)
+	second := now.Add(600 * time.Millisecond)
+
+	// default precision collapses sub-second events
+	if periods.TimePrecision() != time.Second {
+		t.Logf("default precision should be one second, got %s", periods.TimePrecision())
+		t.Fail()
+	} else if !periods.NewFinitePeriod(first, second, true, false).IsEmpty() {
+		t.Log("sub-second period should be empty with default precision")
+		t.Fail()
+	}
+
+	// explicit precision keeps them
+	value := periods.NewFinitePeriodWithPrecision(first, second, true, false, time.Millisecond)
+	if value.IsEmpty() || !value.Contains(first) || value.Contains(second) {
+		t.Logf("millisecond period failed, got %s", value.AsRawString())
+		t.Fail()
+	}
+
+	// package precision applies to periods built afterwards
+	periods.SetTimePrecision(time.Millisecond)
+	defer periods.SetTimePrecision(0)
+	other := periods.NewFinitePeriod(first, second, true, false)
+	if !other.Equals(value) {
+		t.Logf("expected %s, got %s", value.AsRawString(), other.AsRawString())
+		t.Fail()
+	} else if !other.Union(periods.NewPeriodSince(second, true)).Equals(periods.NewPeriodSince(first, true)) {
+		t.Log("union at millisecond precision failed")
+		t.Fail()
+	}
+
+	periods.SetTimePrecision(-1)
+	if periods.TimePrecision() != time.Second {
+		t.Log("non positive precision should restore default")
+		t.Fail()
+	}
+}
+
+func TestPeriodPrecisionIsKept(t *testing.T) {
+	// default package precision is one second, period is built at millisecond precision
+	now := time.Now().Truncate(time.Hour)
+	value := periods.NewFinitePeriodWithPrecision(now.Add(100*time.Millisecond), now.Add(900*time.Millisecond), true, false, time.Millisecond)
+
+	expected := periods.NewFinitePeriodWithPrecision(now.Add(time.Hour+100*time.Millisecond), now.Add(time.Hour+900*time.Millisecond), true, false, time.Millisecond)
+	if res := value.Shift(time.Hour); !res.Equals(expected) {
+		t.Errorf("shift lost precision, expected %s got %s", expected.AsRawString(), res.AsRawString())
+	} else if res := value.Shift(time.Hour).Shift(-time.Hour); !res.Equals(value) {
+		t.Errorf("shift round trip failed, got %s", res.AsRawString())
+	}
+
+	expected = periods.NewFinitePeriodWithPrecision(now.Add(50*time.Millisecond), now.Add(time.Second), true, false, time.Millisecond)
+	if res := value.Extend(0, 0); !res.Equals(value) {
+		t.Errorf("extend by nothing should be identity, got %s", res.AsRawString())
+	} else if res := value.Extend(50*time.Millisecond, 100*time.Millisecond); !res.Equals(expected) {
+		t.Errorf("extend lost precision, expected %s got %s", expected.AsRawString(), res.AsRawString())
+	}
+
+	if res, err := periods.PeriodLoad(value.AsStrings()); err != nil {
+		t.Error(err)
+	} else if !res.Equals(value) {
+		t.Errorf("load round trip failed, got %s", res.AsRawString())
+	} else if !res.Contains(now.Add(100*time.Millisecond)) || res.Contains(now.Add(950*time.Millisecond)) {
+		t.Error("loaded period should keep millisecond boundaries")
+	}
+
+	if res, err := periods.NewPeriodFromIntervals(value.Intervals()); err != nil {
+		t.Error(err)
+	} else if !res.Equals(value) {
+		t.Errorf("intervals round trip failed, got %s", res.AsRawString())
+	}
+
+	expected = periods.NewFinitePeriodWithPrecision(now.Add(200*time.Millisecond), now.Add(1800*time.Millisecond), true, false, time.Millisecond)
+	if res, ok := value.ScaleAround(now, 2.0); !ok || !res.Equals(expected) {
+		t.Errorf("scale lost precision, expected %s got %s", expected.AsRawString(), res.AsRawString())
+	}
+
+	// derived periods keep the precision
+	complement := value.Complement()
+	if complement.Contains(now.Add(500*time.Millisecond)) || !complement.Contains(now.Add(950*time.Millisecond)) {
+		t.Errorf("complement lost precision, got %s", complement.AsRawString())
+	} else if !complement.Complement().Equals(value) {
+		t.Errorf("double complement failed, got %s", complement.Complement().AsRawString())
+	}
+
+	builder := periods.NewPeriodBuilderWithPrecision(time.Millisecond)
+	builder.Add(now.Add(100*time.Millisecond), now.Add(900*time.Millisecond), true, false)
+	if res := builder.Build(); !res.Equals(value) {
+		t.Errorf("builder lost precision, got %s", res.AsRawString())
+	}
+}
+
+func TestPeriodContainsUsesPrecision(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+
+	// boundary is truncated to now, so is the point
+	if !periods.NewPeriodUntil(now.Add(700*time.Millisecond), true).Contains(now.Add(500 * time.Millisecond)) {
+		t.Error("point in the same second as an included boundary should be contained")
+	} else if periods.NewPeriodSince(now, false).Contains(now.Add(500 * time.Millisecond)) {
+		t.Error("point in the same second as an excluded boundary should not be contained")
+	} else if !periods.NewPeriodSince(now, false).Contains(now.Add(time.Second)) {
+		t.Error("point after an excluded boundary should be contained")
+	}
+
+	// millisecond precision compares at millisecond level
+	value := periods.NewFinitePeriodWithPrecision(now.Add(100*time.Millisecond), now.Add(900*time.Millisecond), true, false, time.Millisecond)
+	if !value.Contains(now.Add(100*time.Millisecond + 500*time.Microsecond)) {
+		t.Error("point in the same millisecond as the included left boundary should be contained")
+	} else if !value.Contains(now.Add(899 * time.Millisecond)) {
+		t.Error("point before right boundary should be contained")
+	} else if value.Contains(now.Add(900*time.Millisecond + 500*time.Microsecond)) {
+		t.Error("point in the same millisecond as the excluded right boundary should not be contained")
+	}
+}
+
+func TestPeriodMixedPrecisions(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	point := now.Add(500 * time.Millisecond)
+
+	// infinite sides do not change precision of the other boundaries
+	since := periods.NewPeriodSince(now, false)
+	if since.Contains(point) {
+		t.Fatal("point in the same second as an excluded boundary should not be contained")
+	} else if since.Intersection(periods.NewFullPeriod()).Contains(point) {
+		t.Error("intersection with full period should not change the period")
+	} else if periods.IntersectAll(since, periods.NewFullPeriod()).Contains(point) {
+		t.Error("intersection of all with full period should not change the period")
+	} else if since.Union(periods.NewEmptyPeriod()).Contains(point) {
+		t.Error("union with empty period should not change the period")
+	} else if since.Complement().Complement().Contains(point) {
+		t.Error("double complement should not change the period")
+	}
+
+	// a boundary keeps the precision of the period it comes from
+	seconds := periods.NewFinitePeriod(now, now.Add(5*time.Second), false, false)
+	millis := periods.NewFinitePeriodWithPrecision(now.Add(2300*time.Millisecond), now.Add(2700*time.Millisecond), true, true, time.Millisecond)
+	union := seconds.Union(millis)
+	if union.Contains(point) {
+		t.Error("union should not contain a point of no operand")
+	} else if !union.Equals(seconds) {
+		t.Errorf("union with an included period should not change it, got %v", union.AsStrings())
+	} else if !seconds.Intersection(millis).Equals(millis) {
+		t.Error("intersection with an included period should be that period")
+	}
+
+	// a millisecond boundary is not truncated by a second one
+	left := periods.NewFinitePeriodWithPrecision(now.Add(100*time.Millisecond), now.Add(3*time.Second), true, false, time.Millisecond)
+	mixed := left.Union(seconds)
+	if !mixed.Contains(now.Add(100*time.Millisecond)) || mixed.Contains(now.Add(99*time.Millisecond)) {
+		t.Errorf("left boundary should keep its millisecond precision, got %v", mixed.AsStrings())
+	} else if !mixed.Contains(now.Add(4*time.Second)) || mixed.Contains(now.Add(5*time.Second)) {
+		t.Errorf("right boundary should keep its second precision, got %v", mixed.AsStrings())
+	}
+
+	// equality matches contains: excluded boundary at one second is an included one a second later
+	if !since.Equals(periods.NewPeriodSince(now.Add(time.Second), true)) {
+		t.Error("periods with the same moments should be equal")
+	} else if since.Equals(periods.NewPeriodSinceWithPrecision(now, false, time.Millisecond)) {
+		t.Error("periods with different moments should differ")
+	}
+}
+
+func TestPeriodSymmetricDifference(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	t1 := now.Add(1 * time.Hour)
+	t2 := now.Add(2 * time.Hour)
+	t3 := now.Add(3 * time.Hour)
+	t4 := now.Add(4 * time.Hour)
+
+	// identical periods: nothing in exactly one of them
+	value := periods.NewFinitePeriod(t1, t3, true, true)
+	if res := value.SymmetricDifference(value); !res.IsEmpty() {
+		t.Logf("symmetric difference with itself should be empty, got %s", res.AsRawString())
+		t.Fail()
+	}
+
+	// disjoint periods: union
+	other := periods.NewFinitePeriod(t4, t4.Add(time.Hour), true, false)
+	expected := value.Union(other)
+	if res := value.SymmetricDifference(other); !res.Equals(expected) {
+		t.Logf("disjoint symmetric difference failed, expected %s got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	}
+
+	// overlapping: [t1, t3] and [t2, t4] gives [t1, t2[ U ]t3, t4]
+	other = periods.NewFinitePeriod(t2, t4, true, true)
+	expected = periods.NewFinitePeriod(t1, t2, true, false).Union(periods.NewFinitePeriod(t3, t4, false, true))
+	if res := value.SymmetricDifference(other); !res.Equals(expected) {
+		t.Logf("overlapping symmetric difference failed, expected %s got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	} else if res := other.SymmetricDifference(value); !res.Equals(expected) {
+		t.Logf("symmetric difference should be commutative, got %s", res.AsRawString())
+		t.Fail()
+	}
+
+	// full period: complement of the other
+	expected = value.Complement()
+	if res := periods.NewFullPeriod().SymmetricDifference(value); !res.Equals(expected) {
+		t.Logf("symmetric difference with full failed, expected %s got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	}
+}
+
+func TestPeriodNearestBoundary(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	t1 := now.Add(1 * time.Hour)
+	t2 := now.Add(2 * time.Hour)
+	t3 := now.Add(3 * time.Hour)
+	t5 := now.Add(5 * time.Hour)
+
+	// [t1, t2] U [t3, +oo[ : all components count, not only the bounding period
+	value := periods.NewFinitePeriod(t1, t2, true, true).Union(periods.NewPeriodSince(t3, true))
+	if res, found := value.NearestBoundary(t2.Add(10 * time.Minute)); !found || !res.Equal(t2) {
+		t.Logf("expected %s, got %s", t2, res)
+		t.Fail()
+	} else if res, found := value.NearestBoundary(t5); !found || !res.Equal(t3) {
+		t.Logf("expected %s, got %s", t3, res)
+		t.Fail()
+	}
+
+	// tie between the right boundary t2 and the left boundary t3: left wins
+	if res, found := value.NearestBoundary(t2.Add(30 * time.Minute)); !found || !res.Equal(t3) {
+		t.Logf("tie should prefer left boundary %s, got %s", t3, res)
+		t.Fail()
+	}
+
+	// tie within a single interval: left wins
+	single := periods.NewFinitePeriod(t1, t3, false, false)
+	if res, found := single.NearestBoundary(t2); !found || !res.Equal(t1) {
+		t.Logf("tie should prefer left boundary %s, got %s", t1, res)
+		t.Fail()
+	}
+
+	// no finite boundary
+	if _, found := periods.NewEmptyPeriod().NearestBoundary(now); found {
+		t.Log("empty period has no boundary")
+		t.Fail()
+	} else if _, found := periods.NewFullPeriod().NearestBoundary(now); found {
+		t.Log("full period has no finite boundary")
+		t.Fail()
+	}
+}
+
+func BenchmarkPeriodUnionManyIntervals(b *testing.B) {
+	now := time.Now().Truncate(time.Hour)
+	// 1000 intervals, some overlapping, split in two periods of 500 components
+	var even, odd []string
+	for index := 1000; index > 0; index-- {
+		start := now.Add(time.Duration(index) * time.Hour)
+		end := start.Add(time.Duration(1+index%3) * 30 * time.Minute)
+		serialized := periods.NewFinitePeriod(start, end, true, false).AsStrings()
+		if index%2 == 0 {
+			even = append(even, serialized...)
+		} else {
+			odd = append(odd, serialized...)
+		}
+	}
+
+	first, errFirst := periods.PeriodLoad(even)
+	second, errSecond := periods.PeriodLoad(odd)
+	if errFirst != nil || errSecond != nil {
+		b.Fatal("failed to load periods")
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		first.Union(second)
+	}
+}
+
+func TestPeriodContainsPeriod(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	full := periods.NewFullPeriod()
+	empty := periods.NewEmptyPeriod()
+	tail := periods.NewPeriodSince(now, true)
+	finite := periods.NewFinitePeriod(now, after, true, true)
+
+	if !full.ContainsPeriod(tail) || !full.ContainsPeriod(full) {
+		t.Log("full period should contain any period")
+		t.Fail()
+	} else if tail.ContainsPeriod(full) {
+		t.Log("infinite tail should not contain full period")
+		t.Fail()
+	} else if !tail.ContainsPeriod(finite) || finite.ContainsPeriod(tail) {
+		t.Log("infinite tail should contain finite period, and not the opposite")
+		t.Fail()
+	} else if tail.ContainsPeriod(periods.NewPeriodSince(now, false).Union(periods.NewFinitePeriod(before, now, true, false))) {
+		t.Log("tail should not contain a period starting before it")
+		t.Fail()
+	}
+
+	if !finite.ContainsPeriod(empty) || !full.ContainsPeriod(empty) || !empty.ContainsPeriod(empty) {
+		t.Log("empty period should be contained by any period")
+		t.Fail()
+	} else if empty.ContainsPeriod(finite) || empty.ContainsPeriod(full) {
+		t.Log("empty period should contain nothing but itself")
+		t.Fail()
+	}
+}
+
+func TestPeriodIntervals(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	if res := periods.NewEmptyPeriod().Intervals(); res == nil || len(res) != 0 {
+		t.Log("empty period should have no interval")
+		t.Fail()
+	}
+
+	if res := periods.NewFullPeriod().Intervals(); len(res) != 1 {
+		t.Log("full period should have one interval")
+		t.Fail()
+	} else if res[0].IsLeftFinite() || res[0].IsRightFinite() {
+		t.Log("full period interval should be unbounded")
+		t.Fail()
+	}
+
+	// ]-oo, before[ U [now, after] : sorted
+	value := periods.NewFinitePeriod(now, after, true, true).Union(periods.NewPeriodUntil(before, false))
+	res := value.Intervals()
+	if len(res) != 2 {
+		t.Logf("expected two intervals, got %d", len(res))
+		t.FailNow()
+	} else if res[0].IsLeftFinite() || !res[0].IsRightFinite() || !res[0].RightMoment().Equal(before) || res[0].IsRightIncluded() {
+		t.Log("first interval should be ]-oo, before[")
+		t.Fail()
+	} else if !res[1].IsLeftFinite() || !res[1].LeftMoment().Equal(now) || !res[1].IsLeftIncluded() {
+		t.Log("second interval should start at now, included")
+		t.Fail()
+	} else if !res[1].IsRightFinite() || !res[1].RightMoment().Equal(after) || !res[1].IsRightIncluded() {
+		t.Log("second interval should end at after, included")
+		t.Fail()
+	}
+
+	// round trip
+	if back, err := periods.NewPeriodFromIntervals(res); err != nil {
+		t.Logf("unexpected error %s", err.Error())
+		t.Fail()
+	} else if !back.Equals(value) {
+		t.Logf("round trip failed, got %s", back.AsRawString())
+		t.Fail()
+	}
+}
+
+func TestNewPeriodFromIntervals(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	after := now.Add(2 * time.Hour)
+
+	// overlapping inputs are normalized
+	first, errFirst := periods.NewPeriodInterval(true, now, true, true, now.Add(time.Hour), true)
+	second, errSecond := periods.NewPeriodInterval(true, now.Add(30*time.Minute), false, true, after, false)
+	if errFirst != nil || errSecond != nil {
+		t.Log("unexpected error when building intervals")
+		t.FailNow()
+	}
+
+	expected := periods.NewFinitePeriod(now, after, true, false)
+	if res, err := periods.NewPeriodFromIntervals([]periods.PeriodInterval{first, second}); err != nil {
+		t.Logf("unexpected error %s", err.Error())
+		t.Fail()
+	} else if !res.Equals(expected) || len(res.Intervals()) != 1 {
+		t.Logf("expected %s, got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	}
+
+	// invalid inputs
+	if _, err := periods.NewPeriodInterval(true, after, true, true, now, true); err == nil {
+		t.Log("empty interval should raise an error")
+		t.Fail()
+	} else if _, err := periods.NewPeriodFromIntervals([]periods.PeriodInterval{first, {}}); err == nil {
+		t.Log("zero value interval should raise an error")
+		t.Fail()
+	} else if res, err := periods.NewPeriodFromIntervals(nil); err != nil || !res.IsEmpty() {
+		t.Log("no interval should make an empty period")
+		t.Fail()
+	}
+}
+
+func TestPeriodDuration(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	value := periods.NewFinitePeriod(now, now.Add(time.Hour), true, false).
+		Union(periods.NewFinitePeriod(now.Add(2*time.Hour), now.Add(4*time.Hour), false, true))
+	if res, finite := value.Duration(); !finite || res != 3*time.Hour {
+		t.Logf("expected 3 hours, got %s", res)
+		t.Fail()
+	} else if res, finite := periods.NewEmptyPeriod().Duration(); !finite || res != 0 {
+		t.Log("empty period should last zero")
+		t.Fail()
+	} else if _, finite := periods.NewPeriodSince(now, true).Duration(); finite {
+		t.Log("infinite period should not have a finite duration")
+		t.Fail()
+	}
+}
+
+func TestPeriodDurationOfLongSpans(t *testing.T) {
+	start := time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := periods.NewFinitePeriod(start, start.AddDate(200, 0, 0), true, false)
+	second := periods.NewFinitePeriod(start.AddDate(300, 0, 0), start.AddDate(500, 0, 0), true, false)
+	value := first.Union(second)
+
+	// each span fits in a duration, not their sum
+	if res, finite := first.Duration(); !finite || res <= 0 {
+		t.Errorf("200 years should have a positive duration, got %s", res)
+	} else if res, finite := value.Duration(); !finite || res != math.MaxInt64 {
+		t.Errorf("400 years should reach the maximum duration, got %s", res)
+	} else if res, finite := periods.TotalActiveDuration([]periods.Period{first, second}); !finite || res != math.MaxInt64 {
+		t.Errorf("total should reach the maximum duration, got %s", res)
+	} else if res, finite := value.OverlapDuration(periods.NewFullPeriod()); !finite || res != math.MaxInt64 {
+		t.Errorf("overlap should reach the maximum duration, got %s", res)
+	}
+
+	// a single span longer than the maximum duration
+	if res, finite := periods.NewFinitePeriod(start, start.AddDate(500, 0, 0), true, false).Duration(); !finite || res != math.MaxInt64 {
+		t.Errorf("500 years should reach the maximum duration, got %s", res)
+	}
+
+	// ratio does not depend on the maximum duration
+	within := periods.NewFinitePeriod(start, start.AddDate(800, 0, 0), true, false)
+	if res, bounded := value.CoverageRatio(within); !bounded || math.Abs(res-0.5) > 1e-3 {
+		t.Errorf("expected half coverage, got %f", res)
+	}
+}
+
+func TestPeriodOverlapDuration(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	value := periods.NewFinitePeriod(now, now.Add(3*time.Hour), true, true)
+
+	// partial overlap
+	other := periods.NewPeriodSince(now.Add(2*time.Hour), true)
+	if res, finite := value.OverlapDuration(other); !finite || res != time.Hour {
+		t.Logf("expected one hour, got %s", res)
+		t.Fail()
+	}
+
+	// disjoint
+	other = periods.NewPeriodSince(now.Add(4*time.Hour), true)
+	if res, finite := value.OverlapDuration(other); !finite || res != 0 {
+		t.Logf("disjoint periods should not overlap, got %s", res)
+		t.Fail()
+	}
+
+	// infinite overlap
+	if _, finite := periods.NewPeriodSince(now, true).OverlapDuration(periods.NewFullPeriod()); finite {
+		t.Log("infinite overlap should be flagged")
+		t.Fail()
+	}
+}
+
+func TestPeriodBounds(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	value := periods.NewFinitePeriod(now, after, true, false).Union(periods.NewFinitePeriod(before, now.Add(-time.Minute), false, true))
+	if start, startFinite, end, endFinite := value.Bounds(); !startFinite || !endFinite {
+		t.Log("finite period should have finite bounds")
+		t.Fail()
+	} else if !start.Equal(before) || !end.Equal(after) {
+		t.Logf("expected %s and %s, got %s and %s", before, after, start, end)
+		t.Fail()
+	}
+
+	value = periods.NewPeriodSince(after, true).Union(periods.NewFinitePeriod(before, now, true, true))
+	if start, startFinite, _, endFinite := value.Bounds(); !startFinite || endFinite || !start.Equal(before) {
+		t.Log("expected finite start and infinite end")
+		t.Fail()
+	}
+
+	// truncation matches internal precision
+	if start, _, _, _ := periods.NewPeriodSince(now.Add(1500*time.Millisecond), true).Bounds(); !start.Equal(now.Add(time.Second)) {
+		t.Logf("bounds should be truncated, got %s", start)
+		t.Fail()
+	}
+
+	// empty and full both have no finite bound
+	if _, startFinite, _, endFinite := periods.NewEmptyPeriod().Bounds(); startFinite || endFinite {
+		t.Log("empty period should have no finite bound")
+		t.Fail()
+	} else if _, startFinite, _, endFinite := periods.NewFullPeriod().Bounds(); startFinite || endFinite {
+		t.Log("full period should have no finite bound")
+		t.Fail()
+	}
+}
+
+func TestPeriodIntersectAll(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	t1 := now.Add(1 * time.Hour)
+	t2 := now.Add(2 * time.Hour)
+	t3 := now.Add(3 * time.Hour)
+	t4 := now.Add(4 * time.Hour)
+
+	if !periods.IntersectAll().Equals(periods.NewFullPeriod()) {
+		t.Log("intersection of nothing should be full")
+		t.Fail()
+	}
+
+	// ]-oo, t3] inter [t1, +oo[ inter ([now, t2] U [t3, t4])  = [t1, t2] U [t3]
+	first := periods.NewPeriodUntil(t3, true)
+	second := periods.NewPeriodSince(t1, true)
+	third := periods.NewFinitePeriod(now, t2, true, true).Union(periods.NewFinitePeriod(t3, t4, true, true))
+	expected := periods.NewFinitePeriod(t1, t2, true, true).Union(periods.NewFinitePeriod(t3, t3, true, true))
+	if res := periods.IntersectAll(first, second, third); !res.Equals(expected) {
+		t.Logf("expected %s, got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	} else if res := first.Intersection(second).Intersection(third); !res.Equals(expected) {
+		t.Logf("pairwise intersection should match, got %s", res.AsRawString())
+		t.Fail()
+	}
+
+	if res := periods.IntersectAll(first, periods.NewEmptyPeriod(), second); !res.IsEmpty() {
+		t.Log("intersection with empty should be empty")
+		t.Fail()
+	}
+}
+
+func TestPeriodUnionAll(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	t1 := now.Add(1 * time.Hour)
+	t2 := now.Add(2 * time.Hour)
+	t3 := now.Add(3 * time.Hour)
+
+	if !periods.UnionAll().IsEmpty() {
+		t.Log("union of nothing should be empty")
+		t.Fail()
+	}
+
+	// ]-oo, now] U [t1, t2[ U [t2, t3] U ]t3, +oo[ = ]-oo, now] U [t1, +oo[
+	values := []periods.Period{
+		periods.NewPeriodUntil(now, true),
+		periods.NewFinitePeriod(t1, t2, true, false),
+		periods.NewFinitePeriod(t2, t3, true, true),
+		periods.NewPeriodSince(t3, false),
+	}
+
+	expected := periods.NewPeriodUntil(now, true).Union(periods.NewPeriodSince(t1, true))
+	if res := periods.UnionAll(values...); !res.Equals(expected) {
+		t.Logf("expected %s, got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	} else if res := periods.UnionAll(append(values, periods.NewFinitePeriod(now, t1, false, false))...); !res.Equals(periods.NewFullPeriod()) {
+		t.Logf("expected full period, got %s", res.AsRawString())
+		t.Fail()
+	}
+}
+
+func TestPeriodScaleAround(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	value := periods.NewFinitePeriod(now.Add(time.Hour), now.Add(3*time.Hour), true, false)
+
+	// stretch by 2 around now
+	expected := periods.NewFinitePeriod(now.Add(2*time.Hour), now.Add(6*time.Hour), true, false)
+	if res, bounded := value.ScaleAround(now, 2); !bounded || !res.Equals(expected) {
+		t.Logf("expected %s, got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	} else if start, _, end, _ := res.Bounds(); !start.Equal(now.Add(2*time.Hour)) || !end.Equal(now.Add(6*time.Hour)) {
+		t.Logf("unexpected bounds %s and %s", start, end)
+		t.Fail()
+	}
+
+	// mirror around now
+	expected = periods.NewFinitePeriod(now.Add(-3*time.Hour), now.Add(-time.Hour), false, true)
+	if res, bounded := value.ScaleAround(now, -1); !bounded || !res.Equals(expected) {
+		t.Logf("expected %s, got %s", expected.AsRawString(), res.AsRawString())
+		t.Fail()
+	}
+
+	// unbounded periods cannot be scaled
+	if _, bounded := periods.NewPeriodSince(now, true).ScaleAround(now, 2); bounded {
+		t.Log("unbounded period should not be scaled")
+		t.Fail()
+	} else if res, bounded := periods.NewEmptyPeriod().ScaleAround(now, 2); !bounded || !res.IsEmpty() {
+		t.Log("empty period should scale to empty")
+		t.Fail()
+	}
+}
+
+func TestPeriodBoundaryMoments(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	t1 := now.Add(1 * time.Hour)
+	t2 := now.Add(2 * time.Hour)
+	t3 := now.Add(3 * time.Hour)
+
+	value := periods.NewFinitePeriod(t2, t3, false, true).Union(periods.NewFinitePeriod(now, t1, true, false))
+	expected := []time.Time{now, t1, t2, t3}
+	res := value.BoundaryMoments()
+	if len(res) != len(expected) {
+		t.Logf("expected 4 moments, got %v", res)
+		t.FailNow()
+	}
+
+	for index, moment := range expected {
+		if !res[index].Equal(moment) {
+			t.Logf("moment %d: expected %s, got %s", index, moment, res[index])
+			t.Fail()
+		}
+	}
+
+	// single point is deduplicated, infinite sides are ignored
+	if res := periods.NewFinitePeriod(now, now, true, true).BoundaryMoments(); len(res) != 1 {
+		t.Logf("expected one moment, got %v", res)
+		t.Fail()
+	} else if res := periods.NewFullPeriod().BoundaryMoments(); len(res) != 0 {
+		t.Logf("expected no moment, got %v", res)
+		t.Fail()
+	}
+}
+
+func TestPeriodCoverageRatio(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	within := periods.NewFinitePeriod(now, now.Add(4*time.Hour), true, true)
+
+	if res, bounded := periods.NewFullPeriod().CoverageRatio(within); !bounded || res != 1.0 {
+		t.Logf("full coverage expected, got %f", res)
+		t.Fail()
+	} else if res, bounded := periods.NewPeriodSince(now.Add(2*time.Hour), true).CoverageRatio(within); !bounded || res != 0.5 {
+		t.Logf("half coverage expected, got %f", res)
+		t.Fail()
+	} else if res, bounded := periods.NewPeriodUntil(now, true).CoverageRatio(within); !bounded || res != 0 {
+		t.Logf("no coverage expected, got %f", res)
+		t.Fail()
+	} else if _, bounded := within.CoverageRatio(periods.NewPeriodSince(now, true)); bounded {
+		t.Log("unbounded within should be flagged")
+		t.Fail()
+	}
+}
+
+func TestTotalActiveDuration(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	// 2 hours and 2 hours, overlapping for one hour
+	values := []periods.Period{
+		periods.NewFinitePeriod(now, now.Add(2*time.Hour), true, false),
+		periods.NewFinitePeriod(now.Add(time.Hour), now.Add(3*time.Hour), true, false),
+	}
+
+	if res, finite := periods.TotalActiveDuration(values); !finite || res != 3*time.Hour {
+		t.Logf("expected 3 hours, got %s", res)
+		t.Fail()
+	} else if res, finite := periods.TotalActiveDuration(nil); !finite || res != 0 {
+		t.Logf("expected no duration, got %s", res)
+		t.Fail()
+	} else if _, finite := periods.TotalActiveDuration(append(values, periods.NewPeriodSince(now, true))); finite {
+		t.Log("infinite union should be flagged")
+		t.Fail()
+	}
+}
@@ -0,0 +1,143 @@
+package periods_test
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zefrenchwan/perspectives.git/periods"
+)
+
+func TestPeriodStructuredRoundTrip(t *testing.T) {
+	now := time.Now().Truncate(time.Second).UTC()
+	before := now.Add(-24 * time.Hour)
+	after := now.Add(24 * time.Hour)
+
+	values := map[string]periods.Period{
+		"empty": periods.NewEmptyPeriod(),
+		"full":  periods.NewFullPeriod(),
+		"since": periods.NewPeriodSince(now, true),
+		"until": periods.NewPeriodUntil(now, false),
+		"finite": periods.NewFinitePeriod(before, now, false, true).
+			Union(periods.NewFinitePeriod(after, after.Add(time.Hour), true, false)),
+		"holes": periods.NewFullPeriod().Remove(periods.NewFinitePeriod(before, after, true, true)),
+		// sub-second boundaries under the default one second precision
+		"millis": periods.NewFinitePeriodWithPrecision(now.Add(100*time.Millisecond), now.Add(900*time.Millisecond), true, false, time.Millisecond),
+		"exact":  periods.NewPeriodSinceWithPrecision(now.Add(123*time.Nanosecond), false, 0),
+		// boundaries of one interval coming from periods with different precisions
+		"mixed": periods.NewFinitePeriod(before, now, true, true).
+			Union(periods.NewFinitePeriodWithPrecision(now.Add(250*time.Millisecond), after, true, false, time.Millisecond)),
+	}
+
+	for name, value := range values {
+		segments := value.AsStructured()
+		if result, err := periods.PeriodFromStructured(segments); err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		} else if !result.Equals(value) {
+			t.Errorf("%s: expected %v, got %v", name, value.AsStrings(), result.AsStrings())
+		} else if !slices.Equal(result.Intervals(), value.Intervals()) {
+			t.Errorf("%s: precision should survive the round trip", name)
+		}
+
+		// same through JSON
+		var loaded []periods.PeriodSegment
+		if content, err := json.Marshal(segments); err != nil {
+			t.Errorf("%s: marshal failed %v", name, err)
+		} else if err := json.Unmarshal(content, &loaded); err != nil {
+			t.Errorf("%s: unmarshal failed %v", name, err)
+		} else if result, err := periods.PeriodFromStructured(loaded); err != nil {
+			t.Errorf("%s: unexpected error %v", name, err)
+		} else if !result.Equals(value) {
+			t.Errorf("%s: JSON round trip failed, got %s", name, content)
+		}
+	}
+}
+
+func TestPeriodAsStructured(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	segments := periods.NewFinitePeriod(now, now.Add(time.Hour), true, false).
+		Union(periods.NewPeriodSince(now.Add(2*time.Hour), false)).
+		AsStructured()
+
+	expected := []periods.PeriodSegment{
+		{Start: "2024-01-01T00:00:00Z", StartIncluded: true, End: "2024-01-01T01:00:00Z", StartPrecision: "1s", EndPrecision: "1s"},
+		{Start: "2024-01-01T02:00:00Z", StartPrecision: "1s"},
+	}
+
+	if len(segments) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, segments)
+	}
+
+	for index, segment := range segments {
+		if segment != expected[index] {
+			t.Errorf("expected %v, got %v", expected[index], segment)
+		}
+	}
+
+	if len(periods.NewEmptyPeriod().AsStructured()) != 0 {
+		t.Error("empty period should have no segment")
+	} else if full := periods.NewFullPeriod().AsStructured(); len(full) != 1 || full[0] != (periods.PeriodSegment{}) {
+		t.Errorf("full period should be an unbounded segment, got %v", full)
+	}
+}
+
+func TestPeriodFromStructured(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// overlapping segments are merged
+	segments := []periods.PeriodSegment{
+		{Start: "2024-01-01T00:00:00Z", StartIncluded: true, End: "2024-01-01T02:00:00Z"},
+		{Start: "2024-01-01T01:00:00+01:00", StartIncluded: true, End: "2024-01-01T03:00:00Z"},
+	}
+
+	expected := periods.NewFinitePeriod(now, now.Add(3*time.Hour), true, false)
+	if result, err := periods.PeriodFromStructured(segments); err != nil {
+		t.Error(err)
+	} else if !result.Equals(expected) {
+		t.Errorf("expected %v, got %v", expected.AsStrings(), result.AsStrings())
+	}
+
+	// explicit precision is used, missing one is the package precision
+	segments = []periods.PeriodSegment{{Start: "2024-01-01T00:00:00.250Z", StartIncluded: true, StartPrecision: "1ms"}}
+	expected = periods.NewPeriodSinceWithPrecision(now.Add(250*time.Millisecond), true, time.Millisecond)
+	if result, err := periods.PeriodFromStructured(segments); err != nil {
+		t.Error(err)
+	} else if !result.Equals(expected) {
+		t.Errorf("expected %v, got %v", expected.AsStrings(), result.AsStrings())
+	}
+
+	segments = []periods.PeriodSegment{{Start: "2024-01-01T00:00:00.250Z", StartIncluded: true}}
+	expected = periods.NewPeriodSince(now, true)
+	if result, err := periods.PeriodFromStructured(segments); err != nil {
+		t.Error(err)
+	} else if !result.Equals(expected) {
+		t.Errorf("expected %v, got %v", expected.AsStrings(), result.AsStrings())
+	}
+
+	// inverted or empty segments are rejected
+	segments = []periods.PeriodSegment{
+		{Start: "2024-01-01T00:00:00Z", End: "2024-01-01T01:00:00Z"},
+		{Start: "2024-01-01T02:00:00Z", End: "2024-01-01T01:00:00Z"},
+		{Start: "2024-01-01T00:00:00Z", End: "2024-01-01T00:00:00Z"},
+	}
+
+	if _, err := periods.PeriodFromStructured(segments); err == nil {
+		t.Error("inverted segment should raise an error")
+	} else if message := err.Error(); !strings.Contains(message, "index 1") || !strings.Contains(message, "index 2") {
+		t.Errorf("errors should name each invalid segment, got %v", err)
+	} else if strings.Contains(message, "index 0") {
+		t.Errorf("valid segment should not raise an error, got %v", err)
+	}
+
+	// errors are cumulative, even within a segment
+	segments = []periods.PeriodSegment{{Start: "yesterday", End: "2024-13-01T00:00:00Z"}, {End: "tomorrow", EndPrecision: "often"}}
+	if _, err := periods.PeriodFromStructured(segments); err == nil {
+		t.Error("invalid moments should raise an error")
+	} else if message := err.Error(); !strings.Contains(message, "invalid start at index 0") || !strings.Contains(message, "invalid end at index 0") {
+		t.Errorf("both boundaries of segment 0 should be reported, got %v", err)
+	} else if !strings.Contains(message, "invalid end at index 1") || !strings.Contains(message, "invalid end precision at index 1") {
+		t.Errorf("end and precision of segment 1 should be reported, got %v", err)
+	}
+}
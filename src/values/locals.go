@@ -106,6 +106,9 @@ func newLocalMapping[V Value, P comparable](
 		}
 	}
 
+	// values come from a map, sort nodes so that Range order is stable
+	slices.SortFunc(result.nodes, func(a, b localNode[V]) int { return strings.Compare(a.String(), b.String()) })
+
 	// hash may now be calculated
 	result.hashString = localMappingHash(result)
 